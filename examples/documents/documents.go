@@ -70,3 +70,9 @@ var ExampleContainSaltsDocument = ContainSalts{
 	ValueB: 10,
 	Salts:  []*proofspb.Salt{{Compact: []byte{0, 0, 0, 1}, Value: []byte{0x1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x2}}, {Compact: []byte{0, 0, 0, 2}, Value: []byte{0x3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x4}}},
 }
+
+// EmbeddedDocument embeds a generated message into a Go struct instead of declaring it as a field, so the fields of
+// the embedded ExampleDocument are flattened as the fields of the document
+type EmbeddedDocument struct {
+	ExampleDocument
+}
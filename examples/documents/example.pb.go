@@ -10,7 +10,10 @@ import (
 	proto "github.com/centrifuge/precise-proofs/proofs/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -856,6 +859,124 @@ func (x *Entries) GetSalts() []*proto.Salt {
 	return nil
 }
 
+type CompositeKeyEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Country string `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	Year    int32  `protobuf:"varint,2,opt,name=year,proto3" json:"year,omitempty"`
+	Value   string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *CompositeKeyEntry) Reset() {
+	*x = CompositeKeyEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompositeKeyEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompositeKeyEntry) ProtoMessage() {}
+
+func (x *CompositeKeyEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompositeKeyEntry.ProtoReflect.Descriptor instead.
+func (*CompositeKeyEntry) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CompositeKeyEntry) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CompositeKeyEntry) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *CompositeKeyEntry) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type CompositeKeyDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*CompositeKeyEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Salts   []*proto.Salt        `protobuf:"bytes,2,rep,name=salts,proto3" json:"salts,omitempty"`
+}
+
+func (x *CompositeKeyDocument) Reset() {
+	*x = CompositeKeyDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompositeKeyDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompositeKeyDocument) ProtoMessage() {}
+
+func (x *CompositeKeyDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompositeKeyDocument.ProtoReflect.Descriptor instead.
+func (*CompositeKeyDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CompositeKeyDocument) GetEntries() []*CompositeKeyEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *CompositeKeyDocument) GetSalts() []*proto.Salt {
+	if x != nil {
+		return x.Salts
+	}
+	return nil
+}
+
 type BytesKeyEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -868,7 +989,7 @@ type BytesKeyEntry struct {
 func (x *BytesKeyEntry) Reset() {
 	*x = BytesKeyEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[12]
+		mi := &file_examples_documents_example_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -881,7 +1002,7 @@ func (x *BytesKeyEntry) String() string {
 func (*BytesKeyEntry) ProtoMessage() {}
 
 func (x *BytesKeyEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[12]
+	mi := &file_examples_documents_example_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -894,7 +1015,7 @@ func (x *BytesKeyEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BytesKeyEntry.ProtoReflect.Descriptor instead.
 func (*BytesKeyEntry) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{12}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BytesKeyEntry) GetAddress() []byte {
@@ -923,7 +1044,7 @@ type BytesKeyEntries struct {
 func (x *BytesKeyEntries) Reset() {
 	*x = BytesKeyEntries{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[13]
+		mi := &file_examples_documents_example_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -936,7 +1057,7 @@ func (x *BytesKeyEntries) String() string {
 func (*BytesKeyEntries) ProtoMessage() {}
 
 func (x *BytesKeyEntries) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[13]
+	mi := &file_examples_documents_example_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -949,7 +1070,7 @@ func (x *BytesKeyEntries) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BytesKeyEntries.ProtoReflect.Descriptor instead.
 func (*BytesKeyEntries) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{13}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *BytesKeyEntries) GetEntries() []*BytesKeyEntry {
@@ -979,7 +1100,7 @@ type TwoLevelRepeatedDocument struct {
 func (x *TwoLevelRepeatedDocument) Reset() {
 	*x = TwoLevelRepeatedDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[14]
+		mi := &file_examples_documents_example_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -992,7 +1113,7 @@ func (x *TwoLevelRepeatedDocument) String() string {
 func (*TwoLevelRepeatedDocument) ProtoMessage() {}
 
 func (x *TwoLevelRepeatedDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[14]
+	mi := &file_examples_documents_example_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1005,7 +1126,7 @@ func (x *TwoLevelRepeatedDocument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TwoLevelRepeatedDocument.ProtoReflect.Descriptor instead.
 func (*TwoLevelRepeatedDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{14}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *TwoLevelRepeatedDocument) GetValueA() string {
@@ -1043,7 +1164,7 @@ type SimpleRepeatedDocument struct {
 func (x *SimpleRepeatedDocument) Reset() {
 	*x = SimpleRepeatedDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[15]
+		mi := &file_examples_documents_example_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1056,7 +1177,7 @@ func (x *SimpleRepeatedDocument) String() string {
 func (*SimpleRepeatedDocument) ProtoMessage() {}
 
 func (x *SimpleRepeatedDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[15]
+	mi := &file_examples_documents_example_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1069,7 +1190,7 @@ func (x *SimpleRepeatedDocument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SimpleRepeatedDocument.ProtoReflect.Descriptor instead.
 func (*SimpleRepeatedDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{15}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *SimpleRepeatedDocument) GetValueA() string {
@@ -1115,7 +1236,7 @@ type SimpleMapDocument struct {
 func (x *SimpleMapDocument) Reset() {
 	*x = SimpleMapDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[16]
+		mi := &file_examples_documents_example_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1128,7 +1249,7 @@ func (x *SimpleMapDocument) String() string {
 func (*SimpleMapDocument) ProtoMessage() {}
 
 func (x *SimpleMapDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[16]
+	mi := &file_examples_documents_example_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1141,7 +1262,7 @@ func (x *SimpleMapDocument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SimpleMapDocument.ProtoReflect.Descriptor instead.
 func (*SimpleMapDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{16}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SimpleMapDocument) GetValueA() string {
@@ -1179,6 +1300,108 @@ func (x *SimpleMapDocument) GetSalts() []*proto.Salt {
 	return nil
 }
 
+type StringList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *StringList) Reset() {
+	*x = StringList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StringList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StringList) ProtoMessage() {}
+
+func (x *StringList) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StringList.ProtoReflect.Descriptor instead.
+func (*StringList) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StringList) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type ListMapDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lists map[string]*StringList `protobuf:"bytes,1,rep,name=lists,proto3" json:"lists,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Salts []*proto.Salt          `protobuf:"bytes,2,rep,name=salts,proto3" json:"salts,omitempty"`
+}
+
+func (x *ListMapDocument) Reset() {
+	*x = ListMapDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMapDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMapDocument) ProtoMessage() {}
+
+func (x *ListMapDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMapDocument.ProtoReflect.Descriptor instead.
+func (*ListMapDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListMapDocument) GetLists() map[string]*StringList {
+	if x != nil {
+		return x.Lists
+	}
+	return nil
+}
+
+func (x *ListMapDocument) GetSalts() []*proto.Salt {
+	if x != nil {
+		return x.Salts
+	}
+	return nil
+}
+
 type TwoLevelItem struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1192,7 +1415,7 @@ type TwoLevelItem struct {
 func (x *TwoLevelItem) Reset() {
 	*x = TwoLevelItem{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[17]
+		mi := &file_examples_documents_example_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1205,7 +1428,7 @@ func (x *TwoLevelItem) String() string {
 func (*TwoLevelItem) ProtoMessage() {}
 
 func (x *TwoLevelItem) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[17]
+	mi := &file_examples_documents_example_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1218,7 +1441,7 @@ func (x *TwoLevelItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TwoLevelItem.ProtoReflect.Descriptor instead.
 func (*TwoLevelItem) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{17}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *TwoLevelItem) GetValueA() *SimpleItem {
@@ -1257,7 +1480,7 @@ type NestedRepeatedDocument struct {
 func (x *NestedRepeatedDocument) Reset() {
 	*x = NestedRepeatedDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[18]
+		mi := &file_examples_documents_example_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1270,7 +1493,7 @@ func (x *NestedRepeatedDocument) String() string {
 func (*NestedRepeatedDocument) ProtoMessage() {}
 
 func (x *NestedRepeatedDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[18]
+	mi := &file_examples_documents_example_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1283,7 +1506,7 @@ func (x *NestedRepeatedDocument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NestedRepeatedDocument.ProtoReflect.Descriptor instead.
 func (*NestedRepeatedDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{18}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *NestedRepeatedDocument) GetValueA() string {
@@ -1321,32 +1544,32 @@ func (x *NestedRepeatedDocument) GetSalts() []*proto.Salt {
 	return nil
 }
 
-type InvalidHashedFieldDocument struct {
+type RepeatedHashedFieldDocument struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value string        `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
-	Salts []*proto.Salt `protobuf:"bytes,2,rep,name=salts,proto3" json:"salts,omitempty"`
+	Hashes [][]byte      `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	Salts  []*proto.Salt `protobuf:"bytes,2,rep,name=salts,proto3" json:"salts,omitempty"`
 }
 
-func (x *InvalidHashedFieldDocument) Reset() {
-	*x = InvalidHashedFieldDocument{}
+func (x *RepeatedHashedFieldDocument) Reset() {
+	*x = RepeatedHashedFieldDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[19]
+		mi := &file_examples_documents_example_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *InvalidHashedFieldDocument) String() string {
+func (x *RepeatedHashedFieldDocument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InvalidHashedFieldDocument) ProtoMessage() {}
+func (*RepeatedHashedFieldDocument) ProtoMessage() {}
 
-func (x *InvalidHashedFieldDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[19]
+func (x *RepeatedHashedFieldDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1357,58 +1580,113 @@ func (x *InvalidHashedFieldDocument) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InvalidHashedFieldDocument.ProtoReflect.Descriptor instead.
-func (*InvalidHashedFieldDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use RepeatedHashedFieldDocument.ProtoReflect.Descriptor instead.
+func (*RepeatedHashedFieldDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *InvalidHashedFieldDocument) GetValue() string {
+func (x *RepeatedHashedFieldDocument) GetHashes() [][]byte {
 	if x != nil {
-		return x.Value
+		return x.Hashes
 	}
-	return ""
+	return nil
 }
 
-func (x *InvalidHashedFieldDocument) GetSalts() []*proto.Salt {
+func (x *RepeatedHashedFieldDocument) GetSalts() []*proto.Salt {
 	if x != nil {
 		return x.Salts
 	}
 	return nil
 }
 
-type OneofSample struct {
+type InvalidHashedFieldDocument struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ValueA int32 `protobuf:"varint,1,opt,name=valueA,proto3" json:"valueA,omitempty"`
-	// Types that are assignable to OneofBlock:
-	//	*OneofSample_ValueB
-	//	*OneofSample_ValueC
-	//	*OneofSample_ValueD
-	OneofBlock isOneofSample_OneofBlock `protobuf_oneof:"oneofBlock"`
-	ValueE     int32                    `protobuf:"varint,5,opt,name=valueE,proto3" json:"valueE,omitempty"`
-	Salts      []*proto.Salt            `protobuf:"bytes,6,rep,name=salts,proto3" json:"salts,omitempty"`
+	Value string        `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Salts []*proto.Salt `protobuf:"bytes,2,rep,name=salts,proto3" json:"salts,omitempty"`
 }
 
-func (x *OneofSample) Reset() {
-	*x = OneofSample{}
+func (x *InvalidHashedFieldDocument) Reset() {
+	*x = InvalidHashedFieldDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[20]
+		mi := &file_examples_documents_example_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *OneofSample) String() string {
+func (x *InvalidHashedFieldDocument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OneofSample) ProtoMessage() {}
+func (*InvalidHashedFieldDocument) ProtoMessage() {}
 
-func (x *OneofSample) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[20]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *InvalidHashedFieldDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidHashedFieldDocument.ProtoReflect.Descriptor instead.
+func (*InvalidHashedFieldDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *InvalidHashedFieldDocument) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *InvalidHashedFieldDocument) GetSalts() []*proto.Salt {
+	if x != nil {
+		return x.Salts
+	}
+	return nil
+}
+
+type OneofSample struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValueA int32 `protobuf:"varint,1,opt,name=valueA,proto3" json:"valueA,omitempty"`
+	// Types that are assignable to OneofBlock:
+	//	*OneofSample_ValueB
+	//	*OneofSample_ValueC
+	//	*OneofSample_ValueD
+	OneofBlock isOneofSample_OneofBlock `protobuf_oneof:"oneofBlock"`
+	ValueE     int32                    `protobuf:"varint,5,opt,name=valueE,proto3" json:"valueE,omitempty"`
+	Salts      []*proto.Salt            `protobuf:"bytes,6,rep,name=salts,proto3" json:"salts,omitempty"`
+}
+
+func (x *OneofSample) Reset() {
+	*x = OneofSample{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OneofSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneofSample) ProtoMessage() {}
+
+func (x *OneofSample) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -1420,7 +1698,7 @@ func (x *OneofSample) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OneofSample.ProtoReflect.Descriptor instead.
 func (*OneofSample) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{20}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *OneofSample) GetValueA() int32 {
@@ -1520,7 +1798,7 @@ type LongDocument struct {
 func (x *LongDocument) Reset() {
 	*x = LongDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[21]
+		mi := &file_examples_documents_example_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1533,7 +1811,7 @@ func (x *LongDocument) String() string {
 func (*LongDocument) ProtoMessage() {}
 
 func (x *LongDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[21]
+	mi := &file_examples_documents_example_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1546,7 +1824,7 @@ func (x *LongDocument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LongDocument.ProtoReflect.Descriptor instead.
 func (*LongDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{21}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *LongDocument) GetValue0() int64 {
@@ -1682,7 +1960,7 @@ type Integers struct {
 func (x *Integers) Reset() {
 	*x = Integers{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[22]
+		mi := &file_examples_documents_example_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1695,7 +1973,7 @@ func (x *Integers) String() string {
 func (*Integers) ProtoMessage() {}
 
 func (x *Integers) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[22]
+	mi := &file_examples_documents_example_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1708,7 +1986,7 @@ func (x *Integers) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Integers.ProtoReflect.Descriptor instead.
 func (*Integers) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{22}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *Integers) GetValueA() int32 {
@@ -1801,7 +2079,7 @@ type ContainSalts struct {
 func (x *ContainSalts) Reset() {
 	*x = ContainSalts{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[23]
+		mi := &file_examples_documents_example_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1814,7 +2092,7 @@ func (x *ContainSalts) String() string {
 func (*ContainSalts) ProtoMessage() {}
 
 func (x *ContainSalts) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[23]
+	mi := &file_examples_documents_example_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1827,7 +2105,7 @@ func (x *ContainSalts) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContainSalts.ProtoReflect.Descriptor instead.
 func (*ContainSalts) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{23}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ContainSalts) GetValueA() string {
@@ -1863,7 +2141,7 @@ type ExampleWithoutSalts struct {
 func (x *ExampleWithoutSalts) Reset() {
 	*x = ExampleWithoutSalts{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[24]
+		mi := &file_examples_documents_example_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1876,7 +2154,7 @@ func (x *ExampleWithoutSalts) String() string {
 func (*ExampleWithoutSalts) ProtoMessage() {}
 
 func (x *ExampleWithoutSalts) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[24]
+	mi := &file_examples_documents_example_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1889,7 +2167,7 @@ func (x *ExampleWithoutSalts) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExampleWithoutSalts.ProtoReflect.Descriptor instead.
 func (*ExampleWithoutSalts) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{24}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ExampleWithoutSalts) GetValueA() string {
@@ -1918,7 +2196,7 @@ type Name struct {
 func (x *Name) Reset() {
 	*x = Name{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[25]
+		mi := &file_examples_documents_example_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1931,7 +2209,7 @@ func (x *Name) String() string {
 func (*Name) ProtoMessage() {}
 
 func (x *Name) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[25]
+	mi := &file_examples_documents_example_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1944,7 +2222,7 @@ func (x *Name) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Name.ProtoReflect.Descriptor instead.
 func (*Name) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{25}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *Name) GetFirst() string {
@@ -1974,7 +2252,7 @@ type PhoneNumber struct {
 func (x *PhoneNumber) Reset() {
 	*x = PhoneNumber{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[26]
+		mi := &file_examples_documents_example_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1987,7 +2265,7 @@ func (x *PhoneNumber) String() string {
 func (*PhoneNumber) ProtoMessage() {}
 
 func (x *PhoneNumber) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[26]
+	mi := &file_examples_documents_example_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2000,7 +2278,7 @@ func (x *PhoneNumber) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PhoneNumber.ProtoReflect.Descriptor instead.
 func (*PhoneNumber) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{26}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *PhoneNumber) GetType() string {
@@ -2036,7 +2314,7 @@ type ExampleNested struct {
 func (x *ExampleNested) Reset() {
 	*x = ExampleNested{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[27]
+		mi := &file_examples_documents_example_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2049,7 +2327,7 @@ func (x *ExampleNested) String() string {
 func (*ExampleNested) ProtoMessage() {}
 
 func (x *ExampleNested) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[27]
+	mi := &file_examples_documents_example_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2062,7 +2340,7 @@ func (x *ExampleNested) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExampleNested.ProtoReflect.Descriptor instead.
 func (*ExampleNested) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{27}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ExampleNested) GetHashedValue() []byte {
@@ -2092,7 +2370,7 @@ type AppendFieldDocument struct {
 func (x *AppendFieldDocument) Reset() {
 	*x = AppendFieldDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[28]
+		mi := &file_examples_documents_example_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2105,7 +2383,7 @@ func (x *AppendFieldDocument) String() string {
 func (*AppendFieldDocument) ProtoMessage() {}
 
 func (x *AppendFieldDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[28]
+	mi := &file_examples_documents_example_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2118,7 +2396,7 @@ func (x *AppendFieldDocument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppendFieldDocument.ProtoReflect.Descriptor instead.
 func (*AppendFieldDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{28}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *AppendFieldDocument) GetName() *Name {
@@ -2154,7 +2432,7 @@ type UnsupportedAppendDocument struct {
 func (x *UnsupportedAppendDocument) Reset() {
 	*x = UnsupportedAppendDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[29]
+		mi := &file_examples_documents_example_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2167,7 +2445,7 @@ func (x *UnsupportedAppendDocument) String() string {
 func (*UnsupportedAppendDocument) ProtoMessage() {}
 
 func (x *UnsupportedAppendDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[29]
+	mi := &file_examples_documents_example_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2180,7 +2458,7 @@ func (x *UnsupportedAppendDocument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnsupportedAppendDocument.ProtoReflect.Descriptor instead.
 func (*UnsupportedAppendDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{29}
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *UnsupportedAppendDocument) GetName() *Name {
@@ -2197,33 +2475,33 @@ func (x *UnsupportedAppendDocument) GetNested() *ExampleNested {
 	return nil
 }
 
-type NoSaltDocument struct {
+type NestedAppendInner struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ValueNoSalt string `protobuf:"bytes,1,opt,name=valueNoSalt,proto3" json:"valueNoSalt,omitempty"`
-	ValueSalt   string `protobuf:"bytes,2,opt,name=valueSalt,proto3" json:"valueSalt,omitempty"`
-	Name        *Name  `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	First string `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Name  *Name  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Age   int32  `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
 }
 
-func (x *NoSaltDocument) Reset() {
-	*x = NoSaltDocument{}
+func (x *NestedAppendInner) Reset() {
+	*x = NestedAppendInner{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[30]
+		mi := &file_examples_documents_example_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NoSaltDocument) String() string {
+func (x *NestedAppendInner) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NoSaltDocument) ProtoMessage() {}
+func (*NestedAppendInner) ProtoMessage() {}
 
-func (x *NoSaltDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[30]
+func (x *NestedAppendInner) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2234,59 +2512,58 @@ func (x *NoSaltDocument) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NoSaltDocument.ProtoReflect.Descriptor instead.
-func (*NoSaltDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use NestedAppendInner.ProtoReflect.Descriptor instead.
+func (*NestedAppendInner) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *NoSaltDocument) GetValueNoSalt() string {
+func (x *NestedAppendInner) GetFirst() string {
 	if x != nil {
-		return x.ValueNoSalt
+		return x.First
 	}
 	return ""
 }
 
-func (x *NoSaltDocument) GetValueSalt() string {
+func (x *NestedAppendInner) GetName() *Name {
 	if x != nil {
-		return x.ValueSalt
+		return x.Name
 	}
-	return ""
+	return nil
 }
 
-func (x *NoSaltDocument) GetName() *Name {
+func (x *NestedAppendInner) GetAge() int32 {
 	if x != nil {
-		return x.Name
+		return x.Age
 	}
-	return nil
+	return 0
 }
 
-type ExampleWithPaddingField struct {
+type NestedAppendDocument struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ValueA string        `protobuf:"bytes,1,opt,name=valueA,proto3" json:"valueA,omitempty"`
-	ValueB []byte        `protobuf:"bytes,2,opt,name=valueB,proto3" json:"valueB,omitempty"`
-	Salts  []*proto.Salt `protobuf:"bytes,3,rep,name=salts,proto3" json:"salts,omitempty"`
+	Person *NestedAppendInner `protobuf:"bytes,1,opt,name=person,proto3" json:"person,omitempty"`
+	Salts  []*proto.Salt      `protobuf:"bytes,2,rep,name=salts,proto3" json:"salts,omitempty"`
 }
 
-func (x *ExampleWithPaddingField) Reset() {
-	*x = ExampleWithPaddingField{}
+func (x *NestedAppendDocument) Reset() {
+	*x = NestedAppendDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[31]
+		mi := &file_examples_documents_example_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ExampleWithPaddingField) String() string {
+func (x *NestedAppendDocument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExampleWithPaddingField) ProtoMessage() {}
+func (*NestedAppendDocument) ProtoMessage() {}
 
-func (x *ExampleWithPaddingField) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[31]
+func (x *NestedAppendDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2297,59 +2574,51 @@ func (x *ExampleWithPaddingField) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExampleWithPaddingField.ProtoReflect.Descriptor instead.
-func (*ExampleWithPaddingField) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{31}
-}
-
-func (x *ExampleWithPaddingField) GetValueA() string {
-	if x != nil {
-		return x.ValueA
-	}
-	return ""
+// Deprecated: Use NestedAppendDocument.ProtoReflect.Descriptor instead.
+func (*NestedAppendDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *ExampleWithPaddingField) GetValueB() []byte {
+func (x *NestedAppendDocument) GetPerson() *NestedAppendInner {
 	if x != nil {
-		return x.ValueB
+		return x.Person
 	}
 	return nil
 }
 
-func (x *ExampleWithPaddingField) GetSalts() []*proto.Salt {
+func (x *NestedAppendDocument) GetSalts() []*proto.Salt {
 	if x != nil {
 		return x.Salts
 	}
 	return nil
 }
 
-type NamePadded struct {
+type EnumKeyEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	First string `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
-	Last  string `protobuf:"bytes,2,opt,name=last,proto3" json:"last,omitempty"`
-	Age   int32  `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
+	Type  Enum   `protobuf:"varint,1,opt,name=type,proto3,enum=documents.Enum" json:"type,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *NamePadded) Reset() {
-	*x = NamePadded{}
+func (x *EnumKeyEntry) Reset() {
+	*x = EnumKeyEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[32]
+		mi := &file_examples_documents_example_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NamePadded) String() string {
+func (x *EnumKeyEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NamePadded) ProtoMessage() {}
+func (*EnumKeyEntry) ProtoMessage() {}
 
-func (x *NamePadded) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[32]
+func (x *EnumKeyEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2360,57 +2629,51 @@ func (x *NamePadded) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NamePadded.ProtoReflect.Descriptor instead.
-func (*NamePadded) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use EnumKeyEntry.ProtoReflect.Descriptor instead.
+func (*EnumKeyEntry) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *NamePadded) GetFirst() string {
+func (x *EnumKeyEntry) GetType() Enum {
 	if x != nil {
-		return x.First
+		return x.Type
 	}
-	return ""
+	return Enum_type_one
 }
 
-func (x *NamePadded) GetLast() string {
+func (x *EnumKeyEntry) GetValue() string {
 	if x != nil {
-		return x.Last
+		return x.Value
 	}
 	return ""
 }
 
-func (x *NamePadded) GetAge() int32 {
-	if x != nil {
-		return x.Age
-	}
-	return 0
-}
-
-type AppendFieldPaddingDocument struct {
+type EnumKeyDocument struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Names []*NamePadded `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Entries []*EnumKeyEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Salts   []*proto.Salt   `protobuf:"bytes,2,rep,name=salts,proto3" json:"salts,omitempty"`
 }
 
-func (x *AppendFieldPaddingDocument) Reset() {
-	*x = AppendFieldPaddingDocument{}
+func (x *EnumKeyDocument) Reset() {
+	*x = EnumKeyDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_examples_documents_example_proto_msgTypes[33]
+		mi := &file_examples_documents_example_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AppendFieldPaddingDocument) String() string {
+func (x *EnumKeyDocument) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AppendFieldPaddingDocument) ProtoMessage() {}
+func (*EnumKeyDocument) ProtoMessage() {}
 
-func (x *AppendFieldPaddingDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_examples_documents_example_proto_msgTypes[33]
+func (x *EnumKeyDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2421,480 +2684,1115 @@ func (x *AppendFieldPaddingDocument) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AppendFieldPaddingDocument.ProtoReflect.Descriptor instead.
-func (*AppendFieldPaddingDocument) Descriptor() ([]byte, []int) {
-	return file_examples_documents_example_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use EnumKeyDocument.ProtoReflect.Descriptor instead.
+func (*EnumKeyDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *AppendFieldPaddingDocument) GetNames() []*NamePadded {
+func (x *EnumKeyDocument) GetEntries() []*EnumKeyEntry {
 	if x != nil {
-		return x.Names
+		return x.Entries
 	}
 	return nil
 }
 
-var File_examples_documents_example_proto protoreflect.FileDescriptor
-
-var file_examples_documents_example_proto_rawDesc = []byte{
-	0x0a, 0x20, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x1f, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18,
-	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x6f,
-	0x6f, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x61, 0x6c, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0xb0, 0x04, 0x0a, 0x0f, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x44, 0x6f, 0x63,
-	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a,
-	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x31, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x31, 0x12, 0x16, 0x0a,
-	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x32, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x32, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x62,
-	0x79, 0x74, 0x65, 0x73, 0x31, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x31, 0x12, 0x28, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x43, 0x61, 0x6d, 0x65, 0x6c, 0x43, 0x61, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0f, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x61, 0x6d, 0x65, 0x6c, 0x43, 0x61, 0x73,
-	0x65, 0x64, 0x12, 0x31, 0x0a, 0x11, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6e, 0x6f, 0x74, 0x5f,
-	0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xa0,
-	0xc1, 0xf5, 0x0a, 0x00, 0x52, 0x0f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4e, 0x6f, 0x74, 0x49, 0x67,
-	0x6e, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x69,
-	0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xa0, 0xc1,
-	0xf5, 0x0a, 0x01, 0x52, 0x0c, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65,
-	0x64, 0x12, 0x2f, 0x0a, 0x10, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x68,
-	0x61, 0x73, 0x68, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xa8, 0xc1, 0xf5,
-	0x0a, 0x01, 0x52, 0x0e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4e, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68,
-	0x65, 0x64, 0x12, 0x2c, 0x0a, 0x09, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x08, 0x65, 0x6e, 0x75, 0x6d, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x6f, 0x6f, 0x6c, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x6f, 0x6f, 0x6c, 0x12, 0x2a,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64,
-	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc0,
-	0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x08, 0x70, 0x61,
-	0x64, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0xb0, 0xc1,
-	0xf5, 0x0a, 0x20, 0x52, 0x08, 0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x12, 0x21, 0x0a,
-	0x08, 0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0c, 0x42,
-	0x05, 0xb0, 0xc1, 0xf5, 0x0a, 0x20, 0x52, 0x08, 0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x42,
-	0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73,
-	0x61, 0x6c, 0x74, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x0d, 0x41, 0x6c, 0x6c, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74,
-	0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x44, 0x0a, 0x10, 0x74, 0x69, 0x6d,
-	0x65, 0x5f, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x0e, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
-	0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c,
-	0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61,
-	0x6c, 0x74, 0x73, 0x22, 0x85, 0x01, 0x0a, 0x12, 0x41, 0x6c, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64,
-	0x54, 0x79, 0x70, 0x65, 0x73, 0x53, 0x61, 0x6c, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74,
-	0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x28, 0x0a,
-	0x10, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61,
-	0x6d, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e,
-	0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x24, 0x0a, 0x0a, 0x53,
-	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x41, 0x22, 0x79, 0x0a, 0x0c, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x49, 0x74, 0x65,
-	0x6d, 0x12, 0x2d, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69,
-	0x6d, 0x70, 0x6c, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41,
-	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73,
-	0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x7c, 0x0a, 0x09,
-	0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x12, 0x35, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x2e, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x1a, 0x38, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb3, 0x01, 0x0a, 0x0f, 0x53,
-	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x70, 0x12, 0x42,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
-	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x70, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x42, 0x05, 0xb0, 0xc1, 0xf5, 0x0a, 0x20, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52,
-	0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0xb6, 0x01, 0x0a, 0x09, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x12, 0x35,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
-	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
-	0x4d, 0x61, 0x70, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61,
-	0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x1a, 0x4e, 0x0a, 0x0a, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4b, 0x0a, 0x0b, 0x53, 0x69, 0x6d,
-	0x70, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x72,
-	0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x74,
-	0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x72,
-	0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x7a, 0x0a, 0x0d, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65,
-	0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x45, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x42, 0x13, 0xb0, 0xc1, 0xf5, 0x0a, 0x20, 0xba, 0xc1, 0xf5, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x72,
-	0x79, 0x5f, 0x6b, 0x65, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22,
-	0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
-	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c,
-	0x74, 0x73, 0x22, 0x6c, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x65,
-	0x6e, 0x74, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x65, 0x6e, 0x74, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x41, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41,
-	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x43, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43,
-	0x22, 0x6e, 0x0a, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x07, 0x65,
-	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64,
-	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x13,
-	0xb0, 0xc1, 0xf5, 0x0a, 0x20, 0xba, 0xc1, 0xf5, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f,
-	0x6b, 0x65, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05,
-	0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72,
-	0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73,
-	0x22, 0x3f, 0x0a, 0x0d, 0x42, 0x79, 0x74, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x22, 0x7c, 0x0a, 0x0f, 0x42, 0x79, 0x74, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x12, 0x45, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42,
-	0x11, 0xb0, 0xc1, 0xf5, 0x0a, 0x14, 0xba, 0xc1, 0xf5, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x73,
-	0x61, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f,
-	0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22,
-	0x87, 0x01, 0x0a, 0x18, 0x54, 0x77, 0x6f, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x65,
-	0x61, 0x74, 0x65, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x41, 0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73,
-	0x2e, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61,
-	0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x16, 0x53, 0x69,
-	0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x44, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x42, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x12, 0x22, 0x0a, 0x05,
-	0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72,
-	0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73,
-	0x22, 0xe8, 0x02, 0x0a, 0x11, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x44, 0x6f,
-	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16,
-	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x47, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x44, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x42, 0x05, 0xb0, 0xc1, 0xf5, 0x0a, 0x20, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x12,
-	0x40, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x28, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70,
-	0x6c, 0x65, 0x4d, 0x61, 0x70, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x44, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x44, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05,
-	0x73, 0x61, 0x6c, 0x74, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x1a, 0x39, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x79, 0x0a, 0x0c, 0x54,
-	0x77, 0x6f, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x2d, 0x0a, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6f,
-	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x49, 0x74,
-	0x65, 0x6d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x42, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52,
-	0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x16, 0x4e, 0x65, 0x73, 0x74, 0x65,
-	0x64, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x42, 0x12, 0x2d, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69,
-	0x6d, 0x70, 0x6c, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43,
-	0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x54, 0x77, 0x6f,
-	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x44, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05,
-	0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x5d, 0x0a, 0x1a, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64,
-	0x48, 0x61, 0x73, 0x68, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x05, 0xa8, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73,
-	0x61, 0x6c, 0x74, 0x73, 0x22, 0xd4, 0x01, 0x0a, 0x0b, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x53, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x18, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x18, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43,
-	0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d,
-	0x70, 0x6c, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x48, 0x00, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x44, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c,
-	0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66,
-	0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x42, 0x0c, 0x0a,
-	0x0a, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x9a, 0x03, 0x0a, 0x0c,
-	0x4c, 0x6f, 0x6e, 0x67, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x30, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x30, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x31, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x31, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x32, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x33, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x33, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x34, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x34, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x35, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x35, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x36, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x36, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x37, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x37, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x38, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x38, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x39, 0x18, 0x09,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x39, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x43, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x18, 0x0d,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x45, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x0f, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c,
-	0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x9e, 0x02, 0x0a, 0x08, 0x49, 0x6e, 0x74,
-	0x65, 0x67, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a,
-	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x12, 0x16, 0x0a,
-	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x11, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x12, 0x16, 0x0a,
-	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x46, 0x18, 0x06, 0x20, 0x01, 0x28, 0x12, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x46, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x47, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x07, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x47, 0x12, 0x16, 0x0a,
-	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x18, 0x08, 0x20, 0x01, 0x28, 0x06, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x48, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x49, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x0f, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x49, 0x12, 0x16, 0x0a,
-	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4a, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x10, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x4a, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x0b,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61,
-	0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x0c, 0x43, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x53, 0x61, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c,
-	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66,
-	0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x45, 0x0a,
-	0x13, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x53,
-	0x61, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x42, 0x22, 0x30, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x72,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x22, 0x5b, 0x0a, 0x0b, 0x50, 0x68, 0x6f, 0x6e, 0x65, 0x4e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x72, 0x79, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x22, 0x5e, 0x0a, 0x0d, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4e, 0x65,
-	0x73, 0x74, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0c, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xa8, 0xc1, 0xf5, 0x0a,
-	0x01, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64,
-	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x22, 0xc1, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x46, 0x69,
-	0x65, 0x6c, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc0, 0xc1, 0xf5, 0x0a,
-	0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
-	0x74, 0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc0, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x05,
-	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x50, 0x0a, 0x0d, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x5f, 0x6e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64,
-	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x50, 0x68, 0x6f, 0x6e, 0x65, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x42, 0x13, 0xb0, 0xc1, 0xf5, 0x0a, 0x04, 0xba, 0xc1, 0xf5, 0x0a, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0xc0, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x0c, 0x70, 0x68, 0x6f, 0x6e, 0x65,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x19, 0x55, 0x6e, 0x73, 0x75,
-	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x44, 0x6f, 0x63,
-	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e,
-	0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc0, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x37, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x78,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x05, 0xc0, 0xc1, 0xf5,
-	0x0a, 0x01, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x0e, 0x4e,
-	0x6f, 0x53, 0x61, 0x6c, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a,
-	0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4e, 0x6f, 0x53, 0x61, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x05, 0xc8, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x4e, 0x6f, 0x53, 0x61, 0x6c, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x53,
-	0x61, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x53, 0x61, 0x6c, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e,
-	0x61, 0x6d, 0x65, 0x42, 0x05, 0xc8, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x22, 0x7b, 0x0a, 0x17, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x57, 0x69, 0x74, 0x68, 0x50,
-	0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x0a, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0xb0, 0xc1, 0xf5,
-	0x0a, 0x20, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x1d, 0x0a, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xb0, 0xc1, 0xf5, 0x0a,
-	0x20, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c,
-	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66,
-	0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x56, 0x0a,
-	0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x50, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x05, 0x66,
-	0x69, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0xb0, 0xc1, 0xf5, 0x0a,
-	0x0a, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0xb0, 0xc1, 0xf5, 0x0a, 0x0a, 0x52, 0x04, 0x6c,
-	0x61, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x03, 0x61, 0x67, 0x65, 0x22, 0x50, 0x0a, 0x1a, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x46,
-	0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e,
-	0x61, 0x6d, 0x65, 0x50, 0x61, 0x64, 0x64, 0x65, 0x64, 0x42, 0x05, 0xc0, 0xc1, 0xf5, 0x0a, 0x01,
-	0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x2a, 0x22, 0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12,
-	0x0c, 0x0a, 0x08, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0c, 0x0a,
-	0x08, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x74, 0x77, 0x6f, 0x10, 0x01, 0x42, 0x63, 0x0a, 0x0d, 0x63,
-	0x6f, 0x6d, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x42, 0x0c, 0x45, 0x78,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x42, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x66,
-	0x75, 0x67, 0x65, 0x2f, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x65, 0x2d, 0x70, 0x72, 0x6f, 0x6f,
-	0x66, 0x73, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x3b, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x70, 0x62,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *EnumKeyDocument) GetSalts() []*proto.Salt {
+	if x != nil {
+		return x.Salts
+	}
+	return nil
 }
 
-var (
-	file_examples_documents_example_proto_rawDescOnce sync.Once
-	file_examples_documents_example_proto_rawDescData = file_examples_documents_example_proto_rawDesc
-)
-
-func file_examples_documents_example_proto_rawDescGZIP() []byte {
-	file_examples_documents_example_proto_rawDescOnce.Do(func() {
+type NoSaltDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValueNoSalt string `protobuf:"bytes,1,opt,name=valueNoSalt,proto3" json:"valueNoSalt,omitempty"`
+	ValueSalt   string `protobuf:"bytes,2,opt,name=valueSalt,proto3" json:"valueSalt,omitempty"`
+	Name        *Name  `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *NoSaltDocument) Reset() {
+	*x = NoSaltDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NoSaltDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoSaltDocument) ProtoMessage() {}
+
+func (x *NoSaltDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoSaltDocument.ProtoReflect.Descriptor instead.
+func (*NoSaltDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *NoSaltDocument) GetValueNoSalt() string {
+	if x != nil {
+		return x.ValueNoSalt
+	}
+	return ""
+}
+
+func (x *NoSaltDocument) GetValueSalt() string {
+	if x != nil {
+		return x.ValueSalt
+	}
+	return ""
+}
+
+func (x *NoSaltDocument) GetName() *Name {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+type ExampleWithPaddingField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValueA string        `protobuf:"bytes,1,opt,name=valueA,proto3" json:"valueA,omitempty"`
+	ValueB []byte        `protobuf:"bytes,2,opt,name=valueB,proto3" json:"valueB,omitempty"`
+	Salts  []*proto.Salt `protobuf:"bytes,3,rep,name=salts,proto3" json:"salts,omitempty"`
+}
+
+func (x *ExampleWithPaddingField) Reset() {
+	*x = ExampleWithPaddingField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleWithPaddingField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleWithPaddingField) ProtoMessage() {}
+
+func (x *ExampleWithPaddingField) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleWithPaddingField.ProtoReflect.Descriptor instead.
+func (*ExampleWithPaddingField) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ExampleWithPaddingField) GetValueA() string {
+	if x != nil {
+		return x.ValueA
+	}
+	return ""
+}
+
+func (x *ExampleWithPaddingField) GetValueB() []byte {
+	if x != nil {
+		return x.ValueB
+	}
+	return nil
+}
+
+func (x *ExampleWithPaddingField) GetSalts() []*proto.Salt {
+	if x != nil {
+		return x.Salts
+	}
+	return nil
+}
+
+type NamePadded struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	First string `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Last  string `protobuf:"bytes,2,opt,name=last,proto3" json:"last,omitempty"`
+	Age   int32  `protobuf:"varint,3,opt,name=age,proto3" json:"age,omitempty"`
+}
+
+func (x *NamePadded) Reset() {
+	*x = NamePadded{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NamePadded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamePadded) ProtoMessage() {}
+
+func (x *NamePadded) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamePadded.ProtoReflect.Descriptor instead.
+func (*NamePadded) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *NamePadded) GetFirst() string {
+	if x != nil {
+		return x.First
+	}
+	return ""
+}
+
+func (x *NamePadded) GetLast() string {
+	if x != nil {
+		return x.Last
+	}
+	return ""
+}
+
+func (x *NamePadded) GetAge() int32 {
+	if x != nil {
+		return x.Age
+	}
+	return 0
+}
+
+type AppendFieldPaddingDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Names []*NamePadded `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (x *AppendFieldPaddingDocument) Reset() {
+	*x = AppendFieldPaddingDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppendFieldPaddingDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendFieldPaddingDocument) ProtoMessage() {}
+
+func (x *AppendFieldPaddingDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendFieldPaddingDocument.ProtoReflect.Descriptor instead.
+func (*AppendFieldPaddingDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AppendFieldPaddingDocument) GetNames() []*NamePadded {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type AnyDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValueA  string     `protobuf:"bytes,1,opt,name=valueA,proto3" json:"valueA,omitempty"`
+	Payload *anypb.Any `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *AnyDocument) Reset() {
+	*x = AnyDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnyDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnyDocument) ProtoMessage() {}
+
+func (x *AnyDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnyDocument.ProtoReflect.Descriptor instead.
+func (*AnyDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AnyDocument) GetValueA() string {
+	if x != nil {
+		return x.ValueA
+	}
+	return ""
+}
+
+func (x *AnyDocument) GetPayload() *anypb.Any {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type WrapperDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   *wrapperspb.StringValue `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Amount *wrapperspb.Int64Value  `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Active *wrapperspb.BoolValue   `protobuf:"bytes,3,opt,name=active,proto3" json:"active,omitempty"`
+	Salts  []*proto.Salt           `protobuf:"bytes,4,rep,name=salts,proto3" json:"salts,omitempty"`
+}
+
+func (x *WrapperDocument) Reset() {
+	*x = WrapperDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WrapperDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WrapperDocument) ProtoMessage() {}
+
+func (x *WrapperDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WrapperDocument.ProtoReflect.Descriptor instead.
+func (*WrapperDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *WrapperDocument) GetName() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *WrapperDocument) GetAmount() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *WrapperDocument) GetActive() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Active
+	}
+	return nil
+}
+
+func (x *WrapperDocument) GetSalts() []*proto.Salt {
+	if x != nil {
+		return x.Salts
+	}
+	return nil
+}
+
+type LengthFieldDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []string      `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	Length string        `protobuf:"bytes,2,opt,name=length,proto3" json:"length,omitempty"`
+	Salts  []*proto.Salt `protobuf:"bytes,3,rep,name=salts,proto3" json:"salts,omitempty"`
+}
+
+func (x *LengthFieldDocument) Reset() {
+	*x = LengthFieldDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LengthFieldDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LengthFieldDocument) ProtoMessage() {}
+
+func (x *LengthFieldDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LengthFieldDocument.ProtoReflect.Descriptor instead.
+func (*LengthFieldDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *LengthFieldDocument) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *LengthFieldDocument) GetLength() string {
+	if x != nil {
+		return x.Length
+	}
+	return ""
+}
+
+func (x *LengthFieldDocument) GetSalts() []*proto.Salt {
+	if x != nil {
+		return x.Salts
+	}
+	return nil
+}
+
+type DurationDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Timeout *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Salts   []*proto.Salt        `protobuf:"bytes,3,rep,name=salts,proto3" json:"salts,omitempty"`
+}
+
+func (x *DurationDocument) Reset() {
+	*x = DurationDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DurationDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DurationDocument) ProtoMessage() {}
+
+func (x *DurationDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DurationDocument.ProtoReflect.Descriptor instead.
+func (*DurationDocument) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DurationDocument) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DurationDocument) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *DurationDocument) GetSalts() []*proto.Salt {
+	if x != nil {
+		return x.Salts
+	}
+	return nil
+}
+
+var File_examples_documents_example_proto protoreflect.FileDescriptor
+
+var file_examples_documents_example_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x19, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61,
+	0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70,
+	0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x73, 0x61, 0x6c, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb0, 0x04, 0x0a,
+	0x0f, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x31, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x31, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x32, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x32,
+	0x12, 0x21, 0x0a, 0x0c, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x31,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x31, 0x12, 0x28, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x61, 0x6d, 0x65,
+	0x6c, 0x43, 0x61, 0x73, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x43, 0x61, 0x6d, 0x65, 0x6c, 0x43, 0x61, 0x73, 0x65, 0x64, 0x12, 0x31, 0x0a,
+	0x11, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xa0, 0xc1, 0xf5, 0x0a, 0x00, 0x52,
+	0x0f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4e, 0x6f, 0x74, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64,
+	0x12, 0x2a, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xa0, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x0c,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x10,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xa8, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x0e, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x4e, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x64, 0x12, 0x2c, 0x0a,
+	0x09, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x6e, 0x75,
+	0x6d, 0x52, 0x08, 0x65, 0x6e, 0x75, 0x6d, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x6f, 0x6f, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x6f, 0x6f, 0x6c, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc0, 0xc1, 0xf5, 0x0a, 0x01, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x08, 0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67,
+	0x41, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0xb0, 0xc1, 0xf5, 0x0a, 0x20, 0x52, 0x08,
+	0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x41, 0x12, 0x21, 0x0a, 0x08, 0x70, 0x61, 0x64, 0x64,
+	0x69, 0x6e, 0x67, 0x42, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xb0, 0xc1, 0xf5, 0x0a,
+	0x20, 0x52, 0x08, 0x70, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x12, 0x22, 0x0a, 0x05, 0x73,
+	0x61, 0x6c, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22,
+	0x9c, 0x01, 0x0a, 0x0d, 0x41, 0x6c, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x44, 0x0a, 0x10, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65,
+	0x53, 0x74, 0x61, 0x6d, 0x70, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61,
+	0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x85,
+	0x01, 0x0a, 0x12, 0x41, 0x6c, 0x6c, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x54, 0x79, 0x70, 0x65, 0x73,
+	0x53, 0x61, 0x6c, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x73, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x6d, 0x70, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52,
+	0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x24, 0x0a, 0x0a, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x49, 0x74, 0x65, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x22, 0x79, 0x0a, 0x0c,
+	0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x2d, 0x0a, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x49,
+	0x74, 0x65, 0x6d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x42, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74,
+	0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x7c, 0x0a, 0x09, 0x53, 0x69, 0x6d, 0x70, 0x6c,
+	0x65, 0x4d, 0x61, 0x70, 0x12, 0x35, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e,
+	0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x38, 0x0a, 0x0a, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb3, 0x01, 0x0a, 0x0f, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x70, 0x12, 0x42, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x4d, 0x61, 0x70, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42,
+	0x05, 0xb0, 0xc1, 0xf5, 0x0a, 0x20, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x22, 0x0a,
+	0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74,
+	0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb6, 0x01, 0x0a, 0x09,
+	0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x12, 0x35, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73,
+	0x61, 0x6c, 0x74, 0x73, 0x1a, 0x4e, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e,
+	0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x4b, 0x0a, 0x0b, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x4b, 0x65, 0x79,
+	0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0x7a, 0x0a, 0x0d, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x45, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e,
+	0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x13, 0xb0, 0xc1, 0xf5,
+	0x0a, 0x20, 0xba, 0xc1, 0xf5, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79,
+	0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x6c, 0x0a,
+	0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x74, 0x72, 0x79,
+	0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x42, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x22, 0x6e, 0x0a, 0x07, 0x45,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x13, 0xb0, 0xc1, 0xf5, 0x0a, 0x20,
+	0xba, 0xc1, 0xf5, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x52, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e,
+	0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x57, 0x0a, 0x11, 0x43,
+	0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x65,
+	0x61, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x85, 0x01, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x65, 0x4b, 0x65, 0x79, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x49, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x11, 0xba, 0xc1,
+	0xf5, 0x0a, 0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x2c, 0x79, 0x65, 0x61, 0x72, 0x52,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73,
+	0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x3f, 0x0a, 0x0d,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x7c, 0x0a,
+	0x0f, 0x42, 0x79, 0x74, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x45, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x11, 0xb0, 0xc1, 0xf5,
+	0x0a, 0x14, 0xba, 0xc1, 0xf5, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e,
+	0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x18,
+	0x54, 0x77, 0x6f, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41,
+	0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x70,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x42, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05,
+	0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x16, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65,
+	0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73,
+	0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0xe8, 0x02, 0x0a,
+	0x11, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x12, 0x47, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53,
+	0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61, 0x70, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x05, 0xb0, 0xc1,
+	0xf5, 0x0a, 0x20, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x12, 0x40, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x44, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x4d, 0x61,
+	0x70, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x12, 0x22, 0x0a,
+	0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74,
+	0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x24, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xca, 0x01,
+	0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x70, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x42, 0x0a, 0x05, 0x6c, 0x69, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x4d, 0x61, 0x70, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x05, 0xb0, 0xc1, 0xf5, 0x0a, 0x08, 0x52, 0x05,
+	0x6c, 0x69, 0x73, 0x74, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61,
+	0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x1a, 0x4f, 0x0a, 0x0a, 0x4c, 0x69, 0x73,
+	0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x79, 0x0a, 0x0c, 0x54, 0x77,
+	0x6f, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x2d, 0x0a, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d, 0x70, 0x6c, 0x65, 0x49, 0x74, 0x65,
+	0x6d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x42, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05,
+	0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x16, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42,
+	0x12, 0x2d, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6d,
+	0x70, 0x6c, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x12,
+	0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x54, 0x77, 0x6f, 0x4c,
+	0x65, 0x76, 0x65, 0x6c, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44,
+	0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73,
+	0x61, 0x6c, 0x74, 0x73, 0x22, 0x60, 0x0a, 0x1b, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x48, 0x61, 0x73, 0x68, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0c, 0x42, 0x05, 0xa8, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68,
+	0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52,
+	0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x5d, 0x0a, 0x1a, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x48, 0x61, 0x73, 0x68, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x05, 0xa8, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05,
+	0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0xd4, 0x01, 0x0a, 0x0b, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x18, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x18, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x43, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x43, 0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x53, 0x69,
+	0x6d, 0x70, 0x6c, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x48, 0x00, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61,
+	0x6c, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x42, 0x0c,
+	0x0a, 0x0a, 0x6f, 0x6e, 0x65, 0x6f, 0x66, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x9a, 0x03, 0x0a,
+	0x0c, 0x4c, 0x6f, 0x6e, 0x67, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x30, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x30, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x31, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x31, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x32, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x33, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x33, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x34, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x34, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x35, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x35, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x36, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x36, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x37, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x37, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x38, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x38, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x39, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x39, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x43, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x45, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x0f,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61,
+	0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x9e, 0x02, 0x0a, 0x08, 0x49, 0x6e,
+	0x74, 0x65, 0x67, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x45,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x11, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x45, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x46, 0x18, 0x06, 0x20, 0x01, 0x28, 0x12, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x46, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x47,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x07, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x47, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x18, 0x08, 0x20, 0x01, 0x28, 0x06, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x49,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0f, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x49, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4a, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x10, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x4a, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53,
+	0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x0c, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x53, 0x61, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x41, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61,
+	0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x45,
+	0x0a, 0x13, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74,
+	0x53, 0x61, 0x6c, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x16, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x22, 0x30, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x22, 0x5b, 0x0a, 0x0b, 0x50, 0x68, 0x6f, 0x6e, 0x65,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x72, 0x79, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x22, 0x5e, 0x0a, 0x0d, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4e,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x0c, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xa8, 0xc1, 0xf5,
+	0x0a, 0x01, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x68, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x23, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x22, 0xc1, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc0, 0xc1, 0xf5,
+	0x0a, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc0, 0xc1, 0xf5, 0x0a, 0x01, 0x52,
+	0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x50, 0x0a, 0x0d, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x50, 0x68, 0x6f, 0x6e, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x13, 0xb0, 0xc1, 0xf5, 0x0a, 0x04, 0xba, 0xc1, 0xf5, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0xc0, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x0c, 0x70, 0x68, 0x6f, 0x6e,
+	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x19, 0x55, 0x6e, 0x73,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc0, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x05, 0xc0, 0xc1,
+	0xf5, 0x0a, 0x01, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x22, 0x67, 0x0a, 0x11, 0x4e,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x6e, 0x65, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc0, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x61, 0x67, 0x65, 0x22, 0x77, 0x0a, 0x14, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x41, 0x70,
+	0x70, 0x65, 0x6e, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x06,
+	0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x41,
+	0x70, 0x70, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x6e, 0x65, 0x72, 0x42, 0x05, 0xc0, 0xc1, 0xf5, 0x0a,
+	0x01, 0x52, 0x06, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x49, 0x0a,
+	0x0c, 0x45, 0x6e, 0x75, 0x6d, 0x4b, 0x65, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x23, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x73, 0x0a, 0x0f, 0x45, 0x6e, 0x75, 0x6d,
+	0x4b, 0x65, 0x79, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x4b, 0x65, 0x79,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x09, 0xba, 0xc1, 0xf5, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66,
+	0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22, 0x83, 0x01,
+	0x0a, 0x0e, 0x4e, 0x6f, 0x53, 0x61, 0x6c, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x27, 0x0a, 0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4e, 0x6f, 0x53, 0x61, 0x6c, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0xc8, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x0b, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x4e, 0x6f, 0x53, 0x61, 0x6c, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x53, 0x61, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x53, 0x61, 0x6c, 0x74, 0x12, 0x2a, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x05, 0xc8, 0xc1, 0xf5, 0x0a, 0x01, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x22, 0x7b, 0x0a, 0x17, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x57, 0x69,
+	0x74, 0x68, 0x50, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05,
+	0xb0, 0xc1, 0xf5, 0x0a, 0x20, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x1d, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x42, 0x05, 0xb0,
+	0xc1, 0xf5, 0x0a, 0x20, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x12, 0x22, 0x0a, 0x05,
+	0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73,
+	0x22, 0x56, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x50, 0x61, 0x64, 0x64, 0x65, 0x64, 0x12, 0x1b,
+	0x0a, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0xb0,
+	0xc1, 0xf5, 0x0a, 0x0a, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x04, 0x6c,
+	0x61, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0xb0, 0xc1, 0xf5, 0x0a, 0x0a,
+	0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x03, 0x61, 0x67, 0x65, 0x22, 0x50, 0x0a, 0x1a, 0x41, 0x70, 0x70, 0x65,
+	0x6e, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x50, 0x61, 0x64, 0x64, 0x65, 0x64, 0x42, 0x05, 0xc0, 0xc1,
+	0xf5, 0x0a, 0x01, 0x52, 0x05, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x22, 0x55, 0x0a, 0x0b, 0x41, 0x6e,
+	0x79, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x41, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x41, 0x12, 0x2e, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x22, 0xd0, 0x01, 0x0a, 0x0f, 0x57, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73,
+	0x61, 0x6c, 0x74, 0x73, 0x22, 0x69, 0x0a, 0x13, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x22, 0x0a, 0x05, 0x73,
+	0x61, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f,
+	0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x22,
+	0x7f, 0x0a, 0x10, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x22, 0x0a, 0x05,
+	0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c, 0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73,
+	0x2a, 0x22, 0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0c, 0x0a, 0x08, 0x74, 0x79, 0x70, 0x65,
+	0x5f, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x74,
+	0x77, 0x6f, 0x10, 0x01, 0x42, 0x63, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x2e, 0x64, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x42, 0x0c, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x66, 0x75, 0x67, 0x65, 0x2f, 0x70, 0x72, 0x65,
+	0x63, 0x69, 0x73, 0x65, 0x2d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2f, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x3b, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_examples_documents_example_proto_rawDescOnce sync.Once
+	file_examples_documents_example_proto_rawDescData = file_examples_documents_example_proto_rawDesc
+)
+
+func file_examples_documents_example_proto_rawDescGZIP() []byte {
+	file_examples_documents_example_proto_rawDescOnce.Do(func() {
 		file_examples_documents_example_proto_rawDescData = protoimpl.X.CompressGZIP(file_examples_documents_example_proto_rawDescData)
 	})
 	return file_examples_documents_example_proto_rawDescData
 }
 
 var file_examples_documents_example_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_examples_documents_example_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_examples_documents_example_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_examples_documents_example_proto_goTypes = []interface{}{
-	(Enum)(0),                          // 0: documents.Enum
-	(*ExampleDocument)(nil),            // 1: documents.ExampleDocument
-	(*AllFieldTypes)(nil),              // 2: documents.AllFieldTypes
-	(*AllFieldTypesSalts)(nil),         // 3: documents.AllFieldTypesSalts
-	(*SimpleItem)(nil),                 // 4: documents.SimpleItem
-	(*RepeatedItem)(nil),               // 5: documents.RepeatedItem
-	(*SimpleMap)(nil),                  // 6: documents.SimpleMap
-	(*SimpleStringMap)(nil),            // 7: documents.SimpleStringMap
-	(*NestedMap)(nil),                  // 8: documents.NestedMap
-	(*SimpleEntry)(nil),                // 9: documents.SimpleEntry
-	(*SimpleEntries)(nil),              // 10: documents.SimpleEntries
-	(*Entry)(nil),                      // 11: documents.Entry
-	(*Entries)(nil),                    // 12: documents.Entries
-	(*BytesKeyEntry)(nil),              // 13: documents.BytesKeyEntry
-	(*BytesKeyEntries)(nil),            // 14: documents.BytesKeyEntries
-	(*TwoLevelRepeatedDocument)(nil),   // 15: documents.TwoLevelRepeatedDocument
-	(*SimpleRepeatedDocument)(nil),     // 16: documents.SimpleRepeatedDocument
-	(*SimpleMapDocument)(nil),          // 17: documents.SimpleMapDocument
-	(*TwoLevelItem)(nil),               // 18: documents.TwoLevelItem
-	(*NestedRepeatedDocument)(nil),     // 19: documents.NestedRepeatedDocument
-	(*InvalidHashedFieldDocument)(nil), // 20: documents.InvalidHashedFieldDocument
-	(*OneofSample)(nil),                // 21: documents.oneofSample
-	(*LongDocument)(nil),               // 22: documents.LongDocument
-	(*Integers)(nil),                   // 23: documents.Integers
-	(*ContainSalts)(nil),               // 24: documents.ContainSalts
-	(*ExampleWithoutSalts)(nil),        // 25: documents.ExampleWithoutSalts
-	(*Name)(nil),                       // 26: documents.Name
-	(*PhoneNumber)(nil),                // 27: documents.PhoneNumber
-	(*ExampleNested)(nil),              // 28: documents.ExampleNested
-	(*AppendFieldDocument)(nil),        // 29: documents.AppendFieldDocument
-	(*UnsupportedAppendDocument)(nil),  // 30: documents.UnsupportedAppendDocument
-	(*NoSaltDocument)(nil),             // 31: documents.NoSaltDocument
-	(*ExampleWithPaddingField)(nil),    // 32: documents.ExampleWithPaddingField
-	(*NamePadded)(nil),                 // 33: documents.NamePadded
-	(*AppendFieldPaddingDocument)(nil), // 34: documents.AppendFieldPaddingDocument
-	nil,                                // 35: documents.SimpleMap.ValueEntry
-	nil,                                // 36: documents.SimpleStringMap.ValueEntry
-	nil,                                // 37: documents.NestedMap.ValueEntry
-	nil,                                // 38: documents.SimpleMapDocument.ValueCEntry
-	nil,                                // 39: documents.SimpleMapDocument.ValueDEntry
-	(*proto.Salt)(nil),                 // 40: proofs.Salt
-	(*timestamppb.Timestamp)(nil),      // 41: google.protobuf.Timestamp
+	(Enum)(0),                           // 0: documents.Enum
+	(*ExampleDocument)(nil),             // 1: documents.ExampleDocument
+	(*AllFieldTypes)(nil),               // 2: documents.AllFieldTypes
+	(*AllFieldTypesSalts)(nil),          // 3: documents.AllFieldTypesSalts
+	(*SimpleItem)(nil),                  // 4: documents.SimpleItem
+	(*RepeatedItem)(nil),                // 5: documents.RepeatedItem
+	(*SimpleMap)(nil),                   // 6: documents.SimpleMap
+	(*SimpleStringMap)(nil),             // 7: documents.SimpleStringMap
+	(*NestedMap)(nil),                   // 8: documents.NestedMap
+	(*SimpleEntry)(nil),                 // 9: documents.SimpleEntry
+	(*SimpleEntries)(nil),               // 10: documents.SimpleEntries
+	(*Entry)(nil),                       // 11: documents.Entry
+	(*Entries)(nil),                     // 12: documents.Entries
+	(*CompositeKeyEntry)(nil),           // 13: documents.CompositeKeyEntry
+	(*CompositeKeyDocument)(nil),        // 14: documents.CompositeKeyDocument
+	(*BytesKeyEntry)(nil),               // 15: documents.BytesKeyEntry
+	(*BytesKeyEntries)(nil),             // 16: documents.BytesKeyEntries
+	(*TwoLevelRepeatedDocument)(nil),    // 17: documents.TwoLevelRepeatedDocument
+	(*SimpleRepeatedDocument)(nil),      // 18: documents.SimpleRepeatedDocument
+	(*SimpleMapDocument)(nil),           // 19: documents.SimpleMapDocument
+	(*StringList)(nil),                  // 20: documents.StringList
+	(*ListMapDocument)(nil),             // 21: documents.ListMapDocument
+	(*TwoLevelItem)(nil),                // 22: documents.TwoLevelItem
+	(*NestedRepeatedDocument)(nil),      // 23: documents.NestedRepeatedDocument
+	(*RepeatedHashedFieldDocument)(nil), // 24: documents.RepeatedHashedFieldDocument
+	(*InvalidHashedFieldDocument)(nil),  // 25: documents.InvalidHashedFieldDocument
+	(*OneofSample)(nil),                 // 26: documents.oneofSample
+	(*LongDocument)(nil),                // 27: documents.LongDocument
+	(*Integers)(nil),                    // 28: documents.Integers
+	(*ContainSalts)(nil),                // 29: documents.ContainSalts
+	(*ExampleWithoutSalts)(nil),         // 30: documents.ExampleWithoutSalts
+	(*Name)(nil),                        // 31: documents.Name
+	(*PhoneNumber)(nil),                 // 32: documents.PhoneNumber
+	(*ExampleNested)(nil),               // 33: documents.ExampleNested
+	(*AppendFieldDocument)(nil),         // 34: documents.AppendFieldDocument
+	(*UnsupportedAppendDocument)(nil),   // 35: documents.UnsupportedAppendDocument
+	(*NestedAppendInner)(nil),           // 36: documents.NestedAppendInner
+	(*NestedAppendDocument)(nil),        // 37: documents.NestedAppendDocument
+	(*EnumKeyEntry)(nil),                // 38: documents.EnumKeyEntry
+	(*EnumKeyDocument)(nil),             // 39: documents.EnumKeyDocument
+	(*NoSaltDocument)(nil),              // 40: documents.NoSaltDocument
+	(*ExampleWithPaddingField)(nil),     // 41: documents.ExampleWithPaddingField
+	(*NamePadded)(nil),                  // 42: documents.NamePadded
+	(*AppendFieldPaddingDocument)(nil),  // 43: documents.AppendFieldPaddingDocument
+	(*AnyDocument)(nil),                 // 44: documents.AnyDocument
+	(*WrapperDocument)(nil),             // 45: documents.WrapperDocument
+	(*LengthFieldDocument)(nil),         // 46: documents.LengthFieldDocument
+	(*DurationDocument)(nil),            // 47: documents.DurationDocument
+	nil,                                 // 48: documents.SimpleMap.ValueEntry
+	nil,                                 // 49: documents.SimpleStringMap.ValueEntry
+	nil,                                 // 50: documents.NestedMap.ValueEntry
+	nil,                                 // 51: documents.SimpleMapDocument.ValueCEntry
+	nil,                                 // 52: documents.SimpleMapDocument.ValueDEntry
+	nil,                                 // 53: documents.ListMapDocument.ListsEntry
+	(*proto.Salt)(nil),                  // 54: proofs.Salt
+	(*timestamppb.Timestamp)(nil),       // 55: google.protobuf.Timestamp
+	(*anypb.Any)(nil),                   // 56: google.protobuf.Any
+	(*wrapperspb.StringValue)(nil),      // 57: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),       // 58: google.protobuf.Int64Value
+	(*wrapperspb.BoolValue)(nil),        // 59: google.protobuf.BoolValue
+	(*durationpb.Duration)(nil),         // 60: google.protobuf.Duration
 }
 var file_examples_documents_example_proto_depIdxs = []int32{
 	0,  // 0: documents.ExampleDocument.enum_type:type_name -> documents.Enum
-	26, // 1: documents.ExampleDocument.name:type_name -> documents.Name
-	40, // 2: documents.ExampleDocument.salts:type_name -> proofs.Salt
-	41, // 3: documents.AllFieldTypes.time_stamp_value:type_name -> google.protobuf.Timestamp
-	40, // 4: documents.AllFieldTypes.salts:type_name -> proofs.Salt
-	40, // 5: documents.AllFieldTypesSalts.salts:type_name -> proofs.Salt
+	31, // 1: documents.ExampleDocument.name:type_name -> documents.Name
+	54, // 2: documents.ExampleDocument.salts:type_name -> proofs.Salt
+	55, // 3: documents.AllFieldTypes.time_stamp_value:type_name -> google.protobuf.Timestamp
+	54, // 4: documents.AllFieldTypes.salts:type_name -> proofs.Salt
+	54, // 5: documents.AllFieldTypesSalts.salts:type_name -> proofs.Salt
 	4,  // 6: documents.RepeatedItem.valueA:type_name -> documents.SimpleItem
-	40, // 7: documents.RepeatedItem.salts:type_name -> proofs.Salt
-	35, // 8: documents.SimpleMap.value:type_name -> documents.SimpleMap.ValueEntry
-	36, // 9: documents.SimpleStringMap.value:type_name -> documents.SimpleStringMap.ValueEntry
-	40, // 10: documents.SimpleStringMap.salts:type_name -> proofs.Salt
-	37, // 11: documents.NestedMap.value:type_name -> documents.NestedMap.ValueEntry
-	40, // 12: documents.NestedMap.salts:type_name -> proofs.Salt
+	54, // 7: documents.RepeatedItem.salts:type_name -> proofs.Salt
+	48, // 8: documents.SimpleMap.value:type_name -> documents.SimpleMap.ValueEntry
+	49, // 9: documents.SimpleStringMap.value:type_name -> documents.SimpleStringMap.ValueEntry
+	54, // 10: documents.SimpleStringMap.salts:type_name -> proofs.Salt
+	50, // 11: documents.NestedMap.value:type_name -> documents.NestedMap.ValueEntry
+	54, // 12: documents.NestedMap.salts:type_name -> proofs.Salt
 	9,  // 13: documents.SimpleEntries.entries:type_name -> documents.SimpleEntry
-	40, // 14: documents.SimpleEntries.salts:type_name -> proofs.Salt
+	54, // 14: documents.SimpleEntries.salts:type_name -> proofs.Salt
 	11, // 15: documents.Entries.entries:type_name -> documents.Entry
-	40, // 16: documents.Entries.salts:type_name -> proofs.Salt
-	13, // 17: documents.BytesKeyEntries.entries:type_name -> documents.BytesKeyEntry
-	40, // 18: documents.BytesKeyEntries.salts:type_name -> proofs.Salt
-	5,  // 19: documents.TwoLevelRepeatedDocument.valueB:type_name -> documents.RepeatedItem
-	40, // 20: documents.TwoLevelRepeatedDocument.salts:type_name -> proofs.Salt
-	40, // 21: documents.SimpleRepeatedDocument.salts:type_name -> proofs.Salt
-	38, // 22: documents.SimpleMapDocument.valueC:type_name -> documents.SimpleMapDocument.ValueCEntry
-	39, // 23: documents.SimpleMapDocument.valueD:type_name -> documents.SimpleMapDocument.ValueDEntry
-	40, // 24: documents.SimpleMapDocument.salts:type_name -> proofs.Salt
-	4,  // 25: documents.TwoLevelItem.valueA:type_name -> documents.SimpleItem
-	40, // 26: documents.TwoLevelItem.salts:type_name -> proofs.Salt
-	4,  // 27: documents.NestedRepeatedDocument.valueC:type_name -> documents.SimpleItem
-	18, // 28: documents.NestedRepeatedDocument.valueD:type_name -> documents.TwoLevelItem
-	40, // 29: documents.NestedRepeatedDocument.salts:type_name -> proofs.Salt
-	40, // 30: documents.InvalidHashedFieldDocument.salts:type_name -> proofs.Salt
-	4,  // 31: documents.oneofSample.valueD:type_name -> documents.SimpleItem
-	40, // 32: documents.oneofSample.salts:type_name -> proofs.Salt
-	40, // 33: documents.LongDocument.salts:type_name -> proofs.Salt
-	40, // 34: documents.Integers.salts:type_name -> proofs.Salt
-	40, // 35: documents.ContainSalts.salts:type_name -> proofs.Salt
-	26, // 36: documents.ExampleNested.name:type_name -> documents.Name
-	26, // 37: documents.AppendFieldDocument.name:type_name -> documents.Name
-	26, // 38: documents.AppendFieldDocument.names:type_name -> documents.Name
-	27, // 39: documents.AppendFieldDocument.phone_numbers:type_name -> documents.PhoneNumber
-	26, // 40: documents.UnsupportedAppendDocument.name:type_name -> documents.Name
-	28, // 41: documents.UnsupportedAppendDocument.nested:type_name -> documents.ExampleNested
-	26, // 42: documents.NoSaltDocument.name:type_name -> documents.Name
-	40, // 43: documents.ExampleWithPaddingField.salts:type_name -> proofs.Salt
-	33, // 44: documents.AppendFieldPaddingDocument.names:type_name -> documents.NamePadded
-	6,  // 45: documents.NestedMap.ValueEntry.value:type_name -> documents.SimpleMap
-	46, // [46:46] is the sub-list for method output_type
-	46, // [46:46] is the sub-list for method input_type
-	46, // [46:46] is the sub-list for extension type_name
-	46, // [46:46] is the sub-list for extension extendee
-	0,  // [0:46] is the sub-list for field type_name
+	54, // 16: documents.Entries.salts:type_name -> proofs.Salt
+	13, // 17: documents.CompositeKeyDocument.entries:type_name -> documents.CompositeKeyEntry
+	54, // 18: documents.CompositeKeyDocument.salts:type_name -> proofs.Salt
+	15, // 19: documents.BytesKeyEntries.entries:type_name -> documents.BytesKeyEntry
+	54, // 20: documents.BytesKeyEntries.salts:type_name -> proofs.Salt
+	5,  // 21: documents.TwoLevelRepeatedDocument.valueB:type_name -> documents.RepeatedItem
+	54, // 22: documents.TwoLevelRepeatedDocument.salts:type_name -> proofs.Salt
+	54, // 23: documents.SimpleRepeatedDocument.salts:type_name -> proofs.Salt
+	51, // 24: documents.SimpleMapDocument.valueC:type_name -> documents.SimpleMapDocument.ValueCEntry
+	52, // 25: documents.SimpleMapDocument.valueD:type_name -> documents.SimpleMapDocument.ValueDEntry
+	54, // 26: documents.SimpleMapDocument.salts:type_name -> proofs.Salt
+	53, // 27: documents.ListMapDocument.lists:type_name -> documents.ListMapDocument.ListsEntry
+	54, // 28: documents.ListMapDocument.salts:type_name -> proofs.Salt
+	4,  // 29: documents.TwoLevelItem.valueA:type_name -> documents.SimpleItem
+	54, // 30: documents.TwoLevelItem.salts:type_name -> proofs.Salt
+	4,  // 31: documents.NestedRepeatedDocument.valueC:type_name -> documents.SimpleItem
+	22, // 32: documents.NestedRepeatedDocument.valueD:type_name -> documents.TwoLevelItem
+	54, // 33: documents.NestedRepeatedDocument.salts:type_name -> proofs.Salt
+	54, // 34: documents.RepeatedHashedFieldDocument.salts:type_name -> proofs.Salt
+	54, // 35: documents.InvalidHashedFieldDocument.salts:type_name -> proofs.Salt
+	4,  // 36: documents.oneofSample.valueD:type_name -> documents.SimpleItem
+	54, // 37: documents.oneofSample.salts:type_name -> proofs.Salt
+	54, // 38: documents.LongDocument.salts:type_name -> proofs.Salt
+	54, // 39: documents.Integers.salts:type_name -> proofs.Salt
+	54, // 40: documents.ContainSalts.salts:type_name -> proofs.Salt
+	31, // 41: documents.ExampleNested.name:type_name -> documents.Name
+	31, // 42: documents.AppendFieldDocument.name:type_name -> documents.Name
+	31, // 43: documents.AppendFieldDocument.names:type_name -> documents.Name
+	32, // 44: documents.AppendFieldDocument.phone_numbers:type_name -> documents.PhoneNumber
+	31, // 45: documents.UnsupportedAppendDocument.name:type_name -> documents.Name
+	33, // 46: documents.UnsupportedAppendDocument.nested:type_name -> documents.ExampleNested
+	31, // 47: documents.NestedAppendInner.name:type_name -> documents.Name
+	36, // 48: documents.NestedAppendDocument.person:type_name -> documents.NestedAppendInner
+	54, // 49: documents.NestedAppendDocument.salts:type_name -> proofs.Salt
+	0,  // 50: documents.EnumKeyEntry.type:type_name -> documents.Enum
+	38, // 51: documents.EnumKeyDocument.entries:type_name -> documents.EnumKeyEntry
+	54, // 52: documents.EnumKeyDocument.salts:type_name -> proofs.Salt
+	31, // 53: documents.NoSaltDocument.name:type_name -> documents.Name
+	54, // 54: documents.ExampleWithPaddingField.salts:type_name -> proofs.Salt
+	42, // 55: documents.AppendFieldPaddingDocument.names:type_name -> documents.NamePadded
+	56, // 56: documents.AnyDocument.payload:type_name -> google.protobuf.Any
+	57, // 57: documents.WrapperDocument.name:type_name -> google.protobuf.StringValue
+	58, // 58: documents.WrapperDocument.amount:type_name -> google.protobuf.Int64Value
+	59, // 59: documents.WrapperDocument.active:type_name -> google.protobuf.BoolValue
+	54, // 60: documents.WrapperDocument.salts:type_name -> proofs.Salt
+	54, // 61: documents.LengthFieldDocument.salts:type_name -> proofs.Salt
+	60, // 62: documents.DurationDocument.timeout:type_name -> google.protobuf.Duration
+	54, // 63: documents.DurationDocument.salts:type_name -> proofs.Salt
+	6,  // 64: documents.NestedMap.ValueEntry.value:type_name -> documents.SimpleMap
+	20, // 65: documents.ListMapDocument.ListsEntry.value:type_name -> documents.StringList
+	66, // [66:66] is the sub-list for method output_type
+	66, // [66:66] is the sub-list for method input_type
+	66, // [66:66] is the sub-list for extension type_name
+	66, // [66:66] is the sub-list for extension extendee
+	0,  // [0:66] is the sub-list for field type_name
 }
 
 func init() { file_examples_documents_example_proto_init() }
@@ -3048,7 +3946,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BytesKeyEntry); i {
+			switch v := v.(*CompositeKeyEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3060,7 +3958,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BytesKeyEntries); i {
+			switch v := v.(*CompositeKeyDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3072,7 +3970,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TwoLevelRepeatedDocument); i {
+			switch v := v.(*BytesKeyEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3084,7 +3982,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SimpleRepeatedDocument); i {
+			switch v := v.(*BytesKeyEntries); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3096,7 +3994,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SimpleMapDocument); i {
+			switch v := v.(*TwoLevelRepeatedDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3108,7 +4006,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TwoLevelItem); i {
+			switch v := v.(*SimpleRepeatedDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3120,7 +4018,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NestedRepeatedDocument); i {
+			switch v := v.(*SimpleMapDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3132,7 +4030,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InvalidHashedFieldDocument); i {
+			switch v := v.(*StringList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3144,7 +4042,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OneofSample); i {
+			switch v := v.(*ListMapDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3156,7 +4054,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LongDocument); i {
+			switch v := v.(*TwoLevelItem); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3168,7 +4066,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Integers); i {
+			switch v := v.(*NestedRepeatedDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3180,7 +4078,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ContainSalts); i {
+			switch v := v.(*RepeatedHashedFieldDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3192,7 +4090,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExampleWithoutSalts); i {
+			switch v := v.(*InvalidHashedFieldDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3204,7 +4102,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Name); i {
+			switch v := v.(*OneofSample); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3216,7 +4114,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PhoneNumber); i {
+			switch v := v.(*LongDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3228,7 +4126,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExampleNested); i {
+			switch v := v.(*Integers); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3240,7 +4138,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendFieldDocument); i {
+			switch v := v.(*ContainSalts); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3252,7 +4150,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnsupportedAppendDocument); i {
+			switch v := v.(*ExampleWithoutSalts); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3264,7 +4162,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NoSaltDocument); i {
+			switch v := v.(*Name); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3276,7 +4174,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExampleWithPaddingField); i {
+			switch v := v.(*PhoneNumber); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3288,7 +4186,7 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NamePadded); i {
+			switch v := v.(*ExampleNested); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3300,6 +4198,114 @@ func file_examples_documents_example_proto_init() {
 			}
 		}
 		file_examples_documents_example_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendFieldDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnsupportedAppendDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NestedAppendInner); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NestedAppendDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnumKeyEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnumKeyDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NoSaltDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleWithPaddingField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NamePadded); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AppendFieldPaddingDocument); i {
 			case 0:
 				return &v.state
@@ -3311,8 +4317,56 @@ func file_examples_documents_example_proto_init() {
 				return nil
 			}
 		}
+		file_examples_documents_example_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnyDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WrapperDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LengthFieldDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_examples_documents_example_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DurationDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_examples_documents_example_proto_msgTypes[20].OneofWrappers = []interface{}{
+	file_examples_documents_example_proto_msgTypes[25].OneofWrappers = []interface{}{
 		(*OneofSample_ValueB)(nil),
 		(*OneofSample_ValueC)(nil),
 		(*OneofSample_ValueD)(nil),
@@ -3323,7 +4377,7 @@ func file_examples_documents_example_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_examples_documents_example_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   39,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
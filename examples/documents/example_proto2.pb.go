@@ -0,0 +1,190 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.19.4
+// source: examples/documents/example_proto2.proto
+
+package documentspb
+
+import (
+	proto "github.com/centrifuge/precise-proofs/proofs/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Proto2Document struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValueA *string       `protobuf:"bytes,1,opt,name=value_a,json=valueA" json:"value_a,omitempty"`
+	ValueB *int64        `protobuf:"varint,2,opt,name=value_b,json=valueB" json:"value_b,omitempty"`
+	ValueC *string       `protobuf:"bytes,3,req,name=value_c,json=valueC" json:"value_c,omitempty"`
+	ValueD *bool         `protobuf:"varint,4,opt,name=value_d,json=valueD" json:"value_d,omitempty"`
+	Salts  []*proto.Salt `protobuf:"bytes,5,rep,name=salts" json:"salts,omitempty"`
+}
+
+func (x *Proto2Document) Reset() {
+	*x = Proto2Document{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_examples_documents_example_proto2_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proto2Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proto2Document) ProtoMessage() {}
+
+func (x *Proto2Document) ProtoReflect() protoreflect.Message {
+	mi := &file_examples_documents_example_proto2_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proto2Document.ProtoReflect.Descriptor instead.
+func (*Proto2Document) Descriptor() ([]byte, []int) {
+	return file_examples_documents_example_proto2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Proto2Document) GetValueA() string {
+	if x != nil && x.ValueA != nil {
+		return *x.ValueA
+	}
+	return ""
+}
+
+func (x *Proto2Document) GetValueB() int64 {
+	if x != nil && x.ValueB != nil {
+		return *x.ValueB
+	}
+	return 0
+}
+
+func (x *Proto2Document) GetValueC() string {
+	if x != nil && x.ValueC != nil {
+		return *x.ValueC
+	}
+	return ""
+}
+
+func (x *Proto2Document) GetValueD() bool {
+	if x != nil && x.ValueD != nil {
+		return *x.ValueD
+	}
+	return false
+}
+
+func (x *Proto2Document) GetSalts() []*proto.Salt {
+	if x != nil {
+		return x.Salts
+	}
+	return nil
+}
+
+var File_examples_documents_example_proto2_proto protoreflect.FileDescriptor
+
+var file_examples_documents_example_proto2_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x1a, 0x17, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x73, 0x61, 0x6c, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x98, 0x01,
+	0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x17, 0x0a, 0x07, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x41, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x5f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x63, 0x18, 0x03, 0x20,
+	0x02, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x12, 0x17, 0x0a, 0x07, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x5f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x44, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x2e, 0x53, 0x61, 0x6c,
+	0x74, 0x52, 0x05, 0x73, 0x61, 0x6c, 0x74, 0x73, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x66, 0x75, 0x67,
+	0x65, 0x2f, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x65, 0x2d, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x73,
+	0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x3b, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x70, 0x62,
+}
+
+var (
+	file_examples_documents_example_proto2_proto_rawDescOnce sync.Once
+	file_examples_documents_example_proto2_proto_rawDescData = file_examples_documents_example_proto2_proto_rawDesc
+)
+
+func file_examples_documents_example_proto2_proto_rawDescGZIP() []byte {
+	file_examples_documents_example_proto2_proto_rawDescOnce.Do(func() {
+		file_examples_documents_example_proto2_proto_rawDescData = protoimpl.X.CompressGZIP(file_examples_documents_example_proto2_proto_rawDescData)
+	})
+	return file_examples_documents_example_proto2_proto_rawDescData
+}
+
+var file_examples_documents_example_proto2_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_examples_documents_example_proto2_proto_goTypes = []interface{}{
+	(*Proto2Document)(nil), // 0: documents.Proto2Document
+	(*proto.Salt)(nil),     // 1: proofs.Salt
+}
+var file_examples_documents_example_proto2_proto_depIdxs = []int32{
+	1, // 0: documents.Proto2Document.salts:type_name -> proofs.Salt
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_examples_documents_example_proto2_proto_init() }
+func file_examples_documents_example_proto2_proto_init() {
+	if File_examples_documents_example_proto2_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_examples_documents_example_proto2_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proto2Document); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_examples_documents_example_proto2_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_examples_documents_example_proto2_proto_goTypes,
+		DependencyIndexes: file_examples_documents_example_proto2_proto_depIdxs,
+		MessageInfos:      file_examples_documents_example_proto2_proto_msgTypes,
+	}.Build()
+	File_examples_documents_example_proto2_proto = out.File
+	file_examples_documents_example_proto2_proto_rawDesc = nil
+	file_examples_documents_example_proto2_proto_goTypes = nil
+	file_examples_documents_example_proto2_proto_depIdxs = nil
+}
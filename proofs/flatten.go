@@ -2,24 +2,46 @@ package proofs
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/json"
 	"fmt"
 	"hash"
+	"math"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
 	"github.com/golang/protobuf/descriptor"
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	godescriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
 	"github.com/golang/protobuf/protoc-gen-go/generator"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/descriptorpb"
 )
 
+// WellKnownEncoder encodes a message of a well-known type as the value of a single leaf
+type WellKnownEncoder func(message proto.Message) ([]byte, error)
+
+// wellKnownEncoders maps the full names of message types to the encoders registered for them
+var wellKnownEncoders = map[string]WellKnownEncoder{}
+
+// RegisterWellKnownEncoder flattens fields of the message type with the given full name, e.g.
+// `google.protobuf.Duration`, to a single leaf with the value returned by fn instead of a leaf per field. Unset fields
+// don't add a leaf. Encoders take precedence over the built-in handling of timestamps and wrapper types.
+func RegisterWellKnownEncoder(fullName string, fn WellKnownEncoder) {
+	wellKnownEncoders[fullName] = fn
+}
+
 // messageFlattener takes a proto.Message and flattens it to a list of ordered nodes.
 type messageFlattener struct {
 	message                      proto.Message
@@ -30,6 +52,24 @@ type messageFlattener struct {
 	hash                         hash.Hash
 	compactProperties            bool
 	fixedLengthFieldLeftPadding  bool
+	sortByCompact                bool
+	leafTransform                LeafTransform
+	strictEnums                  bool
+	includeUnknownFields         bool
+	evmEncoding                  bool
+	lengthEncoder                LengthEncoder
+	omitZeroLengthLeaves         bool
+	deriveLengthSalts            bool
+	typeTagValues                bool
+	enumAsString                 bool
+	excludeFields                map[string]struct{}
+	hashValuesOver               int
+	useJSONNames                 bool
+	normalizeNameCase            func(name string) string
+	saltLength                   int
+	hashSalt                     bool
+	schemeVersion                byte
+	anyResolver                  jsonpb.AnyResolver
 }
 
 func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts Salts, readablePropertyLengthSuffix string, outerFieldDescriptor *godescriptor.FieldDescriptorProto, skipSalts bool) (err error) {
@@ -42,6 +82,16 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 	// Check if we should skip salts from now on
 	skipSalts = skipSalts || getNoSaltFrom(outerFieldDescriptor)
 
+	if message, ok := value.Interface().(proto.Message); ok && len(wellKnownEncoders) > 0 {
+		encode, ok := wellKnownEncoders[string(proto.MessageReflect(message).Descriptor().FullName())]
+		if ok {
+			if value.IsNil() {
+				return nil
+			}
+			return f.appendEncodedValue(prop, encode, message, salts, readablePropertyLengthSuffix, skipSalts)
+		}
+	}
+
 	switch v := value.Interface().(type) {
 	case []byte, *timestamp.Timestamp:
 		var valueBytesArray []byte
@@ -65,22 +115,49 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 		if err != nil {
 			return err
 		}
-		f.appendLeaf(prop, valueBytesArray, salt, readablePropertyLengthSuffix, nil, false)
+		err = f.appendLeaf(prop, valueBytesArray, salt, readablePropertyLengthSuffix, nil, false)
+		if err != nil {
+			return err
+		}
 		return nil
+	case *wrappers.DoubleValue, *wrappers.FloatValue, *wrappers.Int64Value, *wrappers.UInt64Value,
+		*wrappers.Int32Value, *wrappers.UInt32Value, *wrappers.BoolValue, *wrappers.StringValue, *wrappers.BytesValue:
+		// well-known wrapper types are flattened as their inner scalar under the property of the field
+		if value.IsNil() {
+			return nil
+		}
+		return f.handleValue(prop, value.Elem().FieldByName("Value"), salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
+	case *any.Any:
+		if f.anyResolver != nil && v != nil {
+			message, err := f.anyResolver.Resolve(v.TypeUrl)
+			if err != nil {
+				return errors.Wrapf(err, "failed to resolve %q", v.TypeUrl)
+			}
+			err = proto.Unmarshal(v.Value, message)
+			if err != nil {
+				return errors.Wrapf(err, "failed to unpack %q", v.TypeUrl)
+			}
+			return f.handleValue(prop, reflect.ValueOf(message), salts, readablePropertyLengthSuffix, nil, skipSalts)
+		}
 	}
 
 	// handle generic recursive cases
 	switch value.Kind() {
 	case reflect.Ptr:
+		// unset proto2 fields are nil pointers, which don't add a leaf as their element is invalid
 		return f.handleValue(prop, value.Elem(), salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
 	case reflect.Struct:
 
-		// lookup map key from field descriptor, if it exists
-		mappingKeyFieldName := generator.CamelCase(getMappingKeyFrom(outerFieldDescriptor))
+		// lookup map key fields from field descriptor, if they exist
+		mappingKeyFieldNames := make(map[string]struct{})
+		for _, name := range splitMappingKey(getMappingKeyFrom(outerFieldDescriptor)) {
+			mappingKeyFieldNames[generator.CamelCase(name)] = struct{}{}
+		}
 
 		// get append fields extension
 		appendFields := getAppendFieldsFrom(outerFieldDescriptor)
 		fieldMap := make(map[uint32][]byte)
+		fieldNames := make(map[uint32]string)
 
 		_, messageDescriptor := descriptor.ForMessage(value.Addr().Interface().(descriptor.Message))
 
@@ -88,6 +165,15 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 		for i := 0; i < value.NumField(); i++ {
 			oneOfField := false
 			field := value.Type().Field(i)
+			if field.Anonymous && field.IsExported() && field.Tag.Get("protobuf") == "" {
+				// a message embedded into a Go struct adds its fields under the property of the struct, as if the
+				// struct declared them
+				err = f.handleValue(prop, value.Field(i), salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
+				if err != nil {
+					return errors.Wrapf(err, "error handling embedded %s", field.Name)
+				}
+				continue
+			}
 			if field.Tag.Get("protobuf_oneof") != "" {
 				if value.Field(i).IsNil() {
 					continue
@@ -100,8 +186,8 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 				continue
 			}
 
-			if field.Name == mappingKeyFieldName {
-				// this is the map key field
+			if _, ok := mappingKeyFieldNames[field.Name]; ok {
+				// this is a map key field
 				// so we skip flattening this field
 				continue
 			}
@@ -134,6 +220,18 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 			fixedLength := getKeyLengthFrom(innerFieldDescriptor)
 
 			fieldProp := prop.FieldProp(name, num)
+			if f.useJSONNames {
+				md := proto.MessageReflect(value.Addr().Interface().(proto.Message)).Descriptor()
+				fieldProp = prop.FieldProp(md.Fields().ByNumber(protoreflect.FieldNumber(num)).JSONName(), num)
+			}
+			if f.normalizeNameCase != nil {
+				fieldProp.Text = f.normalizeNameCase(fieldProp.Text)
+			}
+			if len(f.excludeFields) > 0 {
+				if _, ok := f.excludeFields[fieldProp.ReadableName()]; ok {
+					continue
+				}
+			}
 
 			isHashed, err := proto.GetExtension(innerFieldDescriptor.Options, proofspb.E_HashedField)
 			if err == nil && *(isHashed.(*bool)) {
@@ -143,6 +241,13 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 				if oneOfField {
 					hashed, ok = value.Field(i).Elem().Elem().Field(0).Interface().([]byte)
 				}
+				if hashes, isSlice := value.Field(i).Interface().([][]byte); isSlice && !appendFields {
+					err = f.handleHashedSlice(fieldProp, hashes, salts, readablePropertyLengthSuffix)
+					if err != nil {
+						return errors.Wrapf(err, "error handling field %s", field.Name)
+					}
+					continue
+				}
 				if !ok {
 					return errors.New("The option hashed_field is only supported for type `bytes`")
 				}
@@ -150,10 +255,14 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 				// if append fields, add it to the fields
 				if appendFields {
 					fieldMap[uint32(num)] = hashed
+					fieldNames[uint32(num)] = name
 					continue
 				}
 
-				f.appendLeaf(fieldProp, []byte{}, nil, readablePropertyLengthSuffix, hashed, true)
+				err = f.appendLeaf(fieldProp, []byte{}, nil, readablePropertyLengthSuffix, hashed, true)
+				if err != nil {
+					return err
+				}
 				continue
 			}
 
@@ -167,9 +276,12 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 			// if append fields are enabled, check if we can append the field
 			if appendFields {
 				var b []byte
-				if fixedLength == 0 {
+				switch {
+				case nextValue.Kind() == reflect.Ptr && getAppendFieldsFrom(innerFieldDescriptor):
+					b, err = f.appendedFieldsValue(fieldProp, nextValue, salts, readablePropertyLengthSuffix, innerFieldDescriptor)
+				case fixedLength == 0:
 					b, err = f.valueToBytesArray(nextValue.Interface())
-				} else {
+				default:
 					b, err = f.valueToPaddingBytesArray(nextValue.Interface(), int(fixedLength))
 				}
 				if err != nil {
@@ -177,6 +289,7 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 				}
 
 				fieldMap[uint32(num)] = b
+				fieldNames[uint32(num)] = name
 				continue
 			}
 
@@ -187,56 +300,46 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 		}
 
 		if !appendFields {
+			if f.includeUnknownFields {
+				unknown := proto.MessageReflect(value.Addr().Interface().(proto.Message)).GetUnknown()
+				return f.handleUnknownFields(prop, unknown, salts, readablePropertyLengthSuffix, skipSalts)
+			}
 			return nil
 		}
 
 		// if append fields enabled, sort and add the field
-		var keys []int
-		for k := range fieldMap {
-			keys = append(keys, int(k))
-		}
-
-		sort.Ints(keys)
-		var finalValue []byte
-		for _, k := range keys {
-			finalValue = append(finalValue, fieldMap[uint32(k)]...)
-		}
-
-		var salt []byte
-		if !skipSalts {
-			salt, err = salts(prop.CompactName())
-			if err != nil {
-				return err
-			}
+		err = f.appendFieldsLeaf(prop, fieldMap, fieldNames, salts, readablePropertyLengthSuffix, skipSalts)
+		if err != nil {
+			return err
 		}
 
-		f.appendLeaf(prop, finalValue, salt, readablePropertyLengthSuffix, nil, false)
-
 	case reflect.Slice:
-		mappingKey := generator.CamelCase(getMappingKeyFrom(outerFieldDescriptor))
+		mappingKey := getMappingKeyFrom(outerFieldDescriptor)
 		if mappingKey != "" {
 			keyLength := getKeyLengthFrom(outerFieldDescriptor)
+			var mappingKeys []string
+			for _, name := range splitMappingKey(mappingKey) {
+				mappingKeys = append(mappingKeys, generator.CamelCase(name))
+			}
 			// a mapping key was defined for this repeated field
 			// convert it to a map, and then handle this value as
 			// a map instead of a slice
-			mapValue, err := sliceToMap(value, mappingKey, keyLength)
+			mapValue, err := sliceToMap(value, mappingKeys, keyLength)
 			if err != nil {
-				return errors.Wrapf(err, "failed to convert %s value to map with mapping_key %q", value.Type(), mappingKey)
+				return errors.Wrapf(err, "failed to convert %s value to map with mapping_key %q", value.Type(), strings.Join(mappingKeys, ","))
 			}
 			return f.handleValue(prop, mapValue, salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
 		}
 
-		// Append length of slice as tree leaf
-		lengthProp := prop.LengthProp(readablePropertyLengthSuffix)
-		lengthBytes, err := toBytesArray(value.Len())
-		if err != nil {
-			return err
+		if f.omitZeroLengthLeaves && value.Len() == 0 {
+			return nil
 		}
-		salt, err := salts(lengthProp.CompactName())
+
+		// Append length of slice as tree leaf
+		err := f.appendLengthLeaf(prop, value.Len(), salts, readablePropertyLengthSuffix)
 		if err != nil {
 			return err
 		}
-		f.appendLeaf(lengthProp, lengthBytes, salt, readablePropertyLengthSuffix, []byte{}, false)
 
 		// Handle each element of the slice
 		for i := 0; i < value.Len(); i++ {
@@ -247,17 +350,15 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 			}
 		}
 	case reflect.Map:
-		// Append size of map as tree leaf
-		lengthProp := prop.LengthProp(readablePropertyLengthSuffix)
-		lengthBytes, err := toBytesArray(value.Len())
-		if err != nil {
-			return err
+		if f.omitZeroLengthLeaves && value.Len() == 0 {
+			return nil
 		}
-		salt, err := salts(lengthProp.CompactName())
+
+		// Append size of map as tree leaf
+		err := f.appendLengthLeaf(prop, value.Len(), salts, readablePropertyLengthSuffix)
 		if err != nil {
 			return err
 		}
-		f.appendLeaf(lengthProp, lengthBytes, salt, readablePropertyLengthSuffix, []byte{}, false)
 
 		// Handle each value of the map
 		for _, k := range value.MapKeys() {
@@ -269,6 +370,13 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 			if err != nil {
 				return errors.Wrapf(err, "failed to create elem prop for %q", k)
 			}
+			if e, ok := k.Interface().(protoreflect.Enum); ok && f.enumAsString {
+				value := e.Descriptor().Values().ByNumber(e.Number())
+				if value == nil {
+					return errors.Errorf("enum value %d is not defined in %s", e.Number(), e.Descriptor().FullName())
+				}
+				elemProp.Text = string(value.Name())
+			}
 			err = f.handleValue(elemProp, value.MapIndex(k), salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
 			if err != nil {
 				return errors.Wrapf(err, "error handling slice element %s", k)
@@ -300,12 +408,166 @@ func (f *messageFlattener) handleValue(prop Property, value reflect.Value, salts
 				return err
 			}
 		}
-		f.appendLeaf(prop, valueBytesArray, salt, readablePropertyLengthSuffix, []byte{}, false)
+		err = f.appendLeaf(prop, valueBytesArray, salt, readablePropertyLengthSuffix, []byte{}, false)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// appendedFieldsValue returns the merged value of a message with the append_fields option that is nested in another
+// message with the option. Its fields are merged in the order of their field numbers like the fields of the parent,
+// and the result is appended to the parent like a scalar field. The LeafTransform and HashValuesOver options only
+// apply to the leaf of the parent.
+func (f *messageFlattener) appendedFieldsValue(prop Property, value reflect.Value, salts Salts, readablePropertyLengthSuffix string, fd *godescriptor.FieldDescriptorProto) ([]byte, error) {
+	return f.nestedAppendedValue(func(nested *messageFlattener) error {
+		return nested.handleValue(prop, value, salts, readablePropertyLengthSuffix, fd, true)
+	})
+}
+
+// nestedAppendedValue flattens a nested message with the append_fields option with a copy of the flattener and
+// returns the merged value of its leaf. It is shared by the flatteners of generated structs and of protoreflect
+// messages.
+func (f *messageFlattener) nestedAppendedValue(flatten func(nested *messageFlattener) error) ([]byte, error) {
+	nested := *f
+	nested.leaves = nil
+	nested.leafTransform = nil
+	nested.hashValuesOver = 0
+	err := flatten(&nested)
+	if err != nil {
+		return nil, err
+	}
+	if len(nested.leaves) == 0 {
+		return nil, nil
+	}
+	return nested.leaves[0].Value, nil
+}
+
+// handleUnknownFields adds a leaf holding the raw bytes of the fields of the message that are not part of its
+// descriptor. No leaf is added if there are no unknown fields.
+func (f *messageFlattener) handleUnknownFields(prop Property, unknown protoreflect.RawFields, salts Salts, readablePropertyLengthSuffix string, skipSalts bool) (err error) {
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	unknownProp := prop.FieldProp(UnknownFieldsName, 0)
+	var salt []byte
+	if !skipSalts {
+		salt, err = salts(unknownProp.CompactName())
+		if err != nil {
+			return err
+		}
+	}
+	return f.appendLeaf(unknownProp, []byte(unknown), salt, readablePropertyLengthSuffix, []byte{}, false)
+}
+
+// handleHashedSlice flattens a repeated hashed field. The length of the field is added as a regular leaf while each
+// element is added as an already hashed leaf.
+func (f *messageFlattener) handleHashedSlice(prop Property, hashes [][]byte, salts Salts, readablePropertyLengthSuffix string) error {
+	if f.omitZeroLengthLeaves && len(hashes) == 0 {
+		return nil
+	}
+
+	err := f.appendLengthLeaf(prop, len(hashes), salts, readablePropertyLengthSuffix)
+	if err != nil {
+		return err
+	}
+
+	for i, hashed := range hashes {
+		err = f.appendLeaf(prop.SliceElemProp(FieldNumForSliceLength(i)), []byte{}, nil, readablePropertyLengthSuffix, hashed, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendLengthLeaf adds the leaf holding the length of a repeated or map field
+func (f *messageFlattener) appendLengthLeaf(prop Property, length int, salts Salts, readablePropertyLengthSuffix string) error {
+	lengthProp := prop.LengthProp(readablePropertyLengthSuffix)
+	lengthBytes, err := f.encodeLength(length)
+	if err != nil {
+		return err
+	}
+	salt, err := salts(lengthProp.CompactName())
+	if err != nil {
+		return err
+	}
+	if f.deriveLengthSalts {
+		salt = DeriveLengthSalt(salt)
+	}
+	return f.appendLeaf(lengthProp, lengthBytes, salt, readablePropertyLengthSuffix, []byte{}, false)
+}
+
+// DeriveLengthSalt returns the salt of the length leaf of a repeated or map field with the given salt if
+// DeriveLengthSalts is set:
+//
+//	SHA256(fieldSalt || "length")
+//
+// which is truncated to the length of the field salt if it is shorter. Fields without a salt result in length leaves
+// without a salt.
+func DeriveLengthSalt(fieldSalt []byte) []byte {
+	if len(fieldSalt) == 0 {
+		return fieldSalt
+	}
+	salt := sha256.Sum256(append(append([]byte(nil), fieldSalt...), "length"...))
+	if len(fieldSalt) < len(salt) {
+		return salt[:len(fieldSalt)]
+	}
+	return salt[:]
+}
+
+// appendFieldsLeaf adds the leaf of a message with the append_fields option. The values of its fields are merged in the
+// order of their field numbers.
+func (f *messageFlattener) appendFieldsLeaf(prop Property, fieldMap map[uint32][]byte, fieldNames map[uint32]string, salts Salts, readablePropertyLengthSuffix string, skipSalts bool) (err error) {
+	var keys []int
+	for k := range fieldMap {
+		keys = append(keys, int(k))
+	}
+
+	sort.Ints(keys)
+	var finalValue []byte
+	components := make([]AppendedField, 0, len(keys))
+	for _, k := range keys {
+		components = append(components, AppendedField{
+			Name:     fieldNames[uint32(k)],
+			FieldNum: FieldNum(k),
+			Start:    len(finalValue),
+			End:      len(finalValue) + len(fieldMap[uint32(k)]),
+		})
+		finalValue = append(finalValue, fieldMap[uint32(k)]...)
+	}
+
+	var salt []byte
+	if !skipSalts {
+		salt, err = salts(prop.CompactName())
+		if err != nil {
+			return err
+		}
+	}
+
+	err = f.appendLeaf(prop, finalValue, salt, readablePropertyLengthSuffix, nil, false)
+	if err != nil {
+		return err
+	}
+	// the ranges only describe the merged value itself, not a transformed value or the hash replacing it
+	leaf := &f.leaves[len(f.leaves)-1]
+	if f.leafTransform == nil && !leaf.Hashed {
+		leaf.Components = components
+	}
+	return nil
+}
+
+// encodeLength returns the value of the length leaf of a repeated or map field
+func (f *messageFlattener) encodeLength(length int) ([]byte, error) {
+	if f.lengthEncoder != nil {
+		return f.lengthEncoder(length)
+	}
+	return toBytesArray(length)
+}
+
 func getInnerFieldDescriptor(descriptorProto *descriptorpb.DescriptorProto, fieldNum int32) (*descriptorpb.FieldDescriptorProto, error) {
 	for _, field := range descriptorProto.GetField() {
 		if field.GetNumber() == fieldNum {
@@ -328,7 +590,38 @@ func fetchLengthFromInterface(k reflect.Value) uint64 {
 	return 0
 }
 
-func (f *messageFlattener) appendLeaf(prop Property, value []byte, salt []byte, readablePropertyLengthSuffix string, hash []byte, hashed bool) {
+// appendEncodedValue adds the leaf of a message encoded by a registered WellKnownEncoder
+func (f *messageFlattener) appendEncodedValue(prop Property, encode WellKnownEncoder, message proto.Message, salts Salts, readablePropertyLengthSuffix string, skipSalts bool) error {
+	value, err := encode(message)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode %s", prop.ReadableName())
+	}
+	var salt []byte
+	if !skipSalts {
+		salt, err = salts(prop.CompactName())
+		if err != nil {
+			return err
+		}
+	}
+	return f.appendLeaf(prop, value, salt, readablePropertyLengthSuffix, nil, false)
+}
+
+func (f *messageFlattener) appendLeaf(prop Property, value []byte, salt []byte, readablePropertyLengthSuffix string, hash []byte, hashed bool) error {
+	if f.leafTransform != nil && !hashed {
+		var err error
+		value, err = f.leafTransform(prop, value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to transform value of %s", prop.ReadableName())
+		}
+	}
+	if f.hashValuesOver > 0 && !hashed && len(value) > f.hashValuesOver {
+		var err error
+		hash, err = sum(f.hash, value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to hash value of %s", prop.ReadableName())
+		}
+		value, salt, hashed = []byte{}, nil, true
+	}
 	leaf := LeafNode{
 		Property: prop,
 		Value:    value,
@@ -337,15 +630,59 @@ func (f *messageFlattener) appendLeaf(prop Property, value []byte, salt []byte,
 		Hashed:   hashed,
 	}
 	f.leaves = append(f.leaves, leaf)
+	return nil
+}
+
+// Type tags prefixed to the values of leaves with the TypeTagValues option
+const (
+	TypeTagBytes byte = iota + 1
+	TypeTagString
+	TypeTagInt
+	TypeTagUint
+	TypeTagBool
+	TypeTagTimestamp
+)
+
+// typeTag returns the type tag of a value accepted by valueToBytesArray
+func typeTag(value interface{}) byte {
+	switch value.(type) {
+	case string:
+		return TypeTagString
+	case int8, int16, int32, int64:
+		return TypeTagInt
+	case uint8, uint16, uint32, uint64:
+		return TypeTagUint
+	case bool:
+		return TypeTagBool
+	case *timestamp.Timestamp:
+		return TypeTagTimestamp
+	}
+	if reflect.ValueOf(value).Kind() == reflect.Int32 {
+		// enums
+		return TypeTagInt
+	}
+	return TypeTagBytes
 }
 
+// valueToBytesArray encodes a scalar value and prefixes it with its type tag if TypeTagValues is enabled
 func (f *messageFlattener) valueToBytesArray(value interface{}) (b []byte, err error) {
+	b, err = f.encodeValue(value)
+	if err != nil || !f.typeTagValues {
+		return b, err
+	}
+	return append([]byte{typeTag(value)}, b...), nil
+}
+
+func (f *messageFlattener) encodeValue(value interface{}) (b []byte, err error) {
 	switch v := value.(type) {
 	case nil:
 		return []byte{}, nil
 	case string:
 		return []byte(v), nil
 	case int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+		if f.evmEncoding {
+			return toEVMWord(v)
+		}
 		return toBytesArray(v)
 	case []byte:
 		return v, nil
@@ -365,8 +702,16 @@ func (f *messageFlattener) valueToBytesArray(value interface{}) (b []byte, err e
 		return toBytesArray(v)
 	default:
 		// special case for enums
+		if e, ok := value.(protoreflect.Enum); ok && f.strictEnums {
+			if e.Descriptor().Values().ByNumber(e.Number()) == nil {
+				return []byte{}, errors.Errorf("enum value %d is not defined in %s", e.Number(), e.Descriptor().FullName())
+			}
+		}
 		rv := reflect.ValueOf(value)
 		if rv.Kind() == reflect.Int32 {
+			if f.evmEncoding {
+				return toEVMWord(rv.Int())
+			}
 			return toBytesArray(rv.Int())
 		}
 
@@ -374,7 +719,17 @@ func (f *messageFlattener) valueToBytesArray(value interface{}) (b []byte, err e
 	}
 }
 
+// valueToPaddingBytesArray encodes a string or bytes value padded to fixedLength and prefixes it with its type tag if
+// TypeTagValues is enabled
 func (f *messageFlattener) valueToPaddingBytesArray(value interface{}, fixedLength int) (b []byte, err error) {
+	b, err = f.encodePaddedValue(value, fixedLength)
+	if err != nil || !f.typeTagValues {
+		return b, err
+	}
+	return append([]byte{typeTag(value)}, b...), nil
+}
+
+func (f *messageFlattener) encodePaddedValue(value interface{}, fixedLength int) (b []byte, err error) {
 	var values []byte
 	switch v := value.(type) {
 	case string:
@@ -399,7 +754,7 @@ func (f *messageFlattener) valueToPaddingBytesArray(value interface{}, fixedLeng
 // sortLeaves by the property attribute and copies the properties and
 // concatenated byte values into the nodes
 func (f *messageFlattener) sortLeaves() (err error) {
-	if f.compactProperties {
+	if f.compactProperties || f.sortByCompact {
 		sort.Sort(sortByCompactName{f.leaves})
 	} else {
 		sort.Sort(sortByReadableName{f.leaves})
@@ -410,7 +765,7 @@ func (f *messageFlattener) sortLeaves() (err error) {
 	for i := 0; i < f.leaves.Len(); i++ {
 		leaf := &f.leaves[i]
 		if len(leaf.Hash) == 0 && !leaf.Hashed {
-			err = leaf.HashNode(f.hash, f.compactProperties)
+			err = leaf.hashNode(f.hash, f.compactProperties, f.hashSalt, f.saltLength, f.schemeVersion)
 			if err != nil {
 				return err
 			}
@@ -432,8 +787,12 @@ func FlattenMessage(message proto.Message, salts Salts, readablePropertyLengthSu
 		compactProperties:            compact,
 		fixedLengthFieldLeftPadding:  fixedLengthFieldLeftPadding,
 	}
+	return f.flatten(message, salts, parentProp)
+}
 
-	err = f.handleValue(parentProp, reflect.ValueOf(message), salts, readablePropertyLengthSuffix, nil, false)
+// flatten walks the message, sorts the resulting leaves and calculates their hashes
+func (f *messageFlattener) flatten(message proto.Message, salts Salts, parentProp Property) (leaves []LeafNode, err error) {
+	err = f.handleValue(parentProp, reflect.ValueOf(message), salts, f.readablePropertyLengthSuffix, nil, false)
 	if err != nil {
 		return
 	}
@@ -445,17 +804,40 @@ func FlattenMessage(message proto.Message, salts Salts, readablePropertyLengthSu
 	return f.leaves, nil
 }
 
-func sliceToMap(value reflect.Value, mappingKey string, keyLength uint64) (reflect.Value, error) {
+func sliceToMap(value reflect.Value, mappingKeys []string, keyLength uint64) (reflect.Value, error) {
 	elemType := value.Type().Elem().Elem()
-	keyField, keyFound := elemType.FieldByName(mappingKey)
-	if !keyFound {
-		return reflect.Value{}, errors.Errorf("%s does not have field %q", elemType, mappingKey)
+	keyFields := make([]reflect.StructField, len(mappingKeys))
+	isMappingKey := make(map[string]struct{}, len(mappingKeys))
+	for i, mappingKey := range mappingKeys {
+		keyField, keyFound := elemType.FieldByName(mappingKey)
+		if !keyFound {
+			return reflect.Value{}, errors.Errorf("%s does not have field %q", elemType, mappingKey)
+		}
+		keyFields[i] = keyField
+		isMappingKey[mappingKey] = struct{}{}
 	}
+	keyField := keyFields[0]
 	keyType := keyField.Type
 	extractKey := func(v reflect.Value) (reflect.Value, error) {
 		return v.Elem().FieldByIndex(keyField.Index), nil
 	}
-	if keyType == reflect.TypeOf([]byte(nil)) {
+	if len(keyFields) > 1 {
+		// composite keys are arrays of their parts, which keyNames handles like a slice of parts. Byte slices are
+		// converted to arrays of their length, so the keys are comparable.
+		keyType = reflect.ArrayOf(len(keyFields), reflect.TypeOf((*interface{})(nil)).Elem())
+		extractKey = func(v reflect.Value) (reflect.Value, error) {
+			key := reflect.New(keyType).Elem()
+			for i, keyField := range keyFields {
+				part := v.Elem().FieldByIndex(keyField.Index)
+				if bs, ok := part.Interface().([]byte); ok {
+					part = reflect.New(reflect.ArrayOf(len(bs), reflect.TypeOf(byte(0)))).Elem()
+					reflect.Copy(part, reflect.ValueOf(bs))
+				}
+				key.Index(i).Set(part)
+			}
+			return key, nil
+		}
+	} else if keyType == reflect.TypeOf([]byte(nil)) {
 		// Go does not allow slices to be the keys of a map
 		// but it does allow arrays to be keys
 		// since we know the key length, we convert each
@@ -496,16 +878,21 @@ func sliceToMap(value reflect.Value, mappingKey string, keyLength uint64) (refle
 
 	_, elemMD := descriptor.ForMessage(reflect.New(elemType).Interface().(descriptor.Message))
 	_, saltsFieldFound := elemType.FieldByName(SaltsFieldName)
-	if (len(elemMD.Field) == 2) || ((len(elemMD.Field) == 3) && (saltsFieldFound)) {
+	valueFields := len(elemMD.Field) - len(mappingKeys)
+	if saltsFieldFound {
+		valueFields--
+	}
+	if valueFields == 1 {
 		valueField, valueFound := elemType.FieldByNameFunc(func(name string) bool {
+			_, isKey := isMappingKey[name]
 			if saltsFieldFound {
-				return !isInternalProtoField(name) && name != mappingKey && name != SaltsFieldName
+				return !isInternalProtoField(name) && !isKey && name != SaltsFieldName
 			} else {
-				return !isInternalProtoField(name) && name != mappingKey
+				return !isInternalProtoField(name) && !isKey
 			}
 		})
 		if !valueFound {
-			return reflect.Value{}, errors.Errorf("could not find field in %s not called %q", elemType, mappingKey)
+			return reflect.Value{}, errors.Errorf("could not find field in %s not called %q", elemType, strings.Join(mappingKeys, ","))
 		}
 		extractValue = func(v reflect.Value) reflect.Value {
 			return v.Elem().FieldByName(valueField.Name)
@@ -568,6 +955,19 @@ func getMappingKeyFrom(fd *godescriptor.FieldDescriptorProto) (mappingKey string
 	return
 }
 
+// splitMappingKey returns the names of the fields of a mapping_key option, which combines several fields into a
+// composite key if they are separated by commas
+func splitMappingKey(mappingKey string) []string {
+	if mappingKey == "" {
+		return nil
+	}
+	names := strings.Split(mappingKey, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	return names
+}
+
 func getAppendFieldsFrom(fd *godescriptor.FieldDescriptorProto) bool {
 	if fd == nil {
 		return false
@@ -594,6 +994,100 @@ func getNoSaltFrom(fd *godescriptor.FieldDescriptorProto) bool {
 	return false
 }
 
+// EncodeValue encodes a typed scalar the same way the flattener encodes field values. Supported types are "string",
+// "int64" and "timestamp". Integers and timestamps can be given as Go numbers, json.Number or decimal strings,
+// timestamps can also be given as time.Time, *timestamp.Timestamp or RFC 3339 strings.
+func EncodeValue(typedValue interface{}, typ string) ([]byte, error) {
+	switch typ {
+	case "string":
+		s, ok := typedValue.(string)
+		if !ok {
+			return nil, errors.Errorf("expected a string value, got %T", typedValue)
+		}
+		return []byte(s), nil
+	case "int64":
+		i, err := toInt64(typedValue)
+		if err != nil {
+			return nil, err
+		}
+		return toBytesArray(i)
+	case "timestamp":
+		var t time.Time
+		switch v := typedValue.(type) {
+		case time.Time:
+			t = v
+		case *timestamp.Timestamp:
+			var err error
+			t, err = ptypes.Timestamp(v)
+			if err != nil {
+				return nil, err
+			}
+		case string:
+			var err error
+			t, err = time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				seconds, intErr := toInt64(v)
+				if intErr != nil {
+					return nil, errors.Wrapf(err, "failed to parse timestamp %q", v)
+				}
+				t = time.Unix(seconds, 0)
+			}
+		default:
+			seconds, err := toInt64(v)
+			if err != nil {
+				return nil, err
+			}
+			t = time.Unix(seconds, 0)
+		}
+		return toBytesArray(t.Unix())
+	default:
+		return nil, errors.Errorf("unsupported value type %q", typ)
+	}
+}
+
+// toInt64 converts integer representations, as they result from decoding JSON, to an int64
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) || v > math.MaxInt64 || v < math.MinInt64 {
+			return 0, errors.Errorf("%v is not an int64 value", v)
+		}
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, errors.Errorf("expected an integer value, got %T", value)
+	}
+}
+
+// toEVMWord encodes an integer as a 32 byte big endian word. Negative values are sign extended (two's complement),
+// matching the EVM's int256.
+func toEVMWord(data interface{}) ([]byte, error) {
+	word := make([]byte, 32)
+	v := reflect.ValueOf(data)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := v.Int()
+		if i < 0 {
+			copy(word, bytes.Repeat([]byte{0xff}, 24))
+		}
+		binary.BigEndian.PutUint64(word[24:], uint64(i))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		binary.BigEndian.PutUint64(word[24:], v.Uint())
+	default:
+		return nil, errors.Errorf("Got unsupported value of type %T", data)
+	}
+	return word, nil
+}
+
 // Utility function to convert data to `[]byte` representation using BigEndian encoding
 func toBytesArray(data interface{}) ([]byte, error) {
 	v := reflect.ValueOf(data)
@@ -0,0 +1,560 @@
+package proofs
+
+import (
+	"fmt"
+	"reflect"
+
+	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
+	"github.com/golang/protobuf/proto"
+	godescriptor "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	timestampMessageName protoreflect.FullName = "google.protobuf.Timestamp"
+	anyMessageName       protoreflect.FullName = "google.protobuf.Any"
+)
+
+// wrapperMessageNames are the well-known wrapper types, which are flattened as their inner scalar
+var wrapperMessageNames = map[protoreflect.FullName]struct{}{
+	"google.protobuf.DoubleValue": {},
+	"google.protobuf.FloatValue":  {},
+	"google.protobuf.Int64Value":  {},
+	"google.protobuf.UInt64Value": {},
+	"google.protobuf.Int32Value":  {},
+	"google.protobuf.UInt32Value": {},
+	"google.protobuf.BoolValue":   {},
+	"google.protobuf.StringValue": {},
+	"google.protobuf.BytesValue":  {},
+}
+
+var saltMessageName = (&proofspb.Salt{}).ProtoReflect().Descriptor().FullName()
+
+// flattenReflect walks the message with the protoreflect API, sorts the resulting leaves and calculates their hashes.
+// The leaves are identical to the ones created by flatten for the same generated message.
+func (f *messageFlattener) flattenReflect(message protoreflect.Message, salts Salts, parentProp Property) (leaves []LeafNode, err error) {
+	err = f.handleReflectMessage(parentProp, message, salts, f.readablePropertyLengthSuffix, nil, false)
+	if err != nil {
+		return
+	}
+
+	err = f.sortLeaves()
+	if err != nil {
+		return []LeafNode{}, err
+	}
+	return f.leaves, nil
+}
+
+// fieldOptions returns a FieldDescriptorProto holding the options of the field, as expected by the getters of the
+// proof options
+func fieldOptions(fd protoreflect.FieldDescriptor) *godescriptor.FieldDescriptorProto {
+	opts, _ := fd.Options().(*descriptorpb.FieldOptions)
+	return &godescriptor.FieldDescriptorProto{Options: opts}
+}
+
+// handleReflectMessage flattens the fields of a message, outerFieldDescriptor is the descriptor of the field holding
+// the message
+func (f *messageFlattener) handleReflectMessage(prop Property, message protoreflect.Message, salts Salts, readablePropertyLengthSuffix string, outerFieldDescriptor *godescriptor.FieldDescriptorProto, skipSalts bool) (err error) {
+	skipSalts = skipSalts || getNoSaltFrom(outerFieldDescriptor)
+	mappingKeys := make(map[string]struct{})
+	for _, name := range splitMappingKey(getMappingKeyFrom(outerFieldDescriptor)) {
+		mappingKeys[name] = struct{}{}
+	}
+	appendFields := getAppendFieldsFrom(outerFieldDescriptor)
+	fieldMap := make(map[uint32][]byte)
+	fieldNames := make(map[uint32]string)
+
+	fields := message.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := string(fd.Name())
+		if fd.ContainingOneof() != nil && !message.Has(fd) {
+			continue
+		}
+
+		if _, ok := mappingKeys[name]; ok {
+			// this is a map key field
+			// so we skip flattening this field
+			continue
+		}
+
+		// if field's name is salts, then bypass flatten this node because it just contain salts
+		if name == "salts" && fd.IsList() {
+			continue
+		}
+
+		innerFieldDescriptor := fieldOptions(fd)
+		excludeFromTree, err := proto.GetExtension(innerFieldDescriptor.Options, proofspb.E_ExcludeFromTree)
+		if err == nil && *(excludeFromTree.(*bool)) {
+			continue
+		}
+
+		fieldProp := prop.FieldProp(name, FieldNum(fd.Number()))
+		if f.useJSONNames {
+			fieldProp = prop.FieldProp(fd.JSONName(), FieldNum(fd.Number()))
+		}
+		if f.normalizeNameCase != nil {
+			fieldProp.Text = f.normalizeNameCase(fieldProp.Text)
+		}
+		if len(f.excludeFields) > 0 {
+			if _, ok := f.excludeFields[fieldProp.ReadableName()]; ok {
+				continue
+			}
+		}
+
+		isHashed, err := proto.GetExtension(innerFieldDescriptor.Options, proofspb.E_HashedField)
+		if err == nil && *(isHashed.(*bool)) {
+			if fd.Kind() != protoreflect.BytesKind || fd.IsMap() || (fd.IsList() && appendFields) {
+				return errors.New("The option hashed_field is only supported for type `bytes`")
+			}
+			if fd.IsList() {
+				list := message.Get(fd).List()
+				hashes := make([][]byte, list.Len())
+				for j := range hashes {
+					hashes[j] = list.Get(j).Bytes()
+				}
+				err = f.handleHashedSlice(fieldProp, hashes, salts, readablePropertyLengthSuffix)
+				if err != nil {
+					return errors.Wrapf(err, "error handling field %s", name)
+				}
+				continue
+			}
+
+			hashed := message.Get(fd).Bytes()
+			if appendFields {
+				fieldMap[uint32(fd.Number())] = hashed
+				fieldNames[uint32(fd.Number())] = name
+				continue
+			}
+			err = f.appendLeaf(fieldProp, []byte{}, nil, readablePropertyLengthSuffix, hashed, true)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// if append fields are enabled, append the field
+		if appendFields {
+			b, err := f.reflectAppendedValue(fieldProp, fd, message.Get(fd), salts, readablePropertyLengthSuffix, innerFieldDescriptor)
+			if err != nil {
+				return errors.Wrapf(err, "failed to append the field %s", name)
+			}
+			fieldMap[uint32(fd.Number())] = b
+			fieldNames[uint32(fd.Number())] = name
+			continue
+		}
+
+		// unset proto2 fields don't add a leaf, unset messages are handled by handleReflectSingular
+		if fd.HasPresence() && fd.Message() == nil && !message.Has(fd) {
+			continue
+		}
+
+		err = f.handleReflectField(fieldProp, fd, message.Get(fd), salts, readablePropertyLengthSuffix, innerFieldDescriptor, skipSalts)
+		if err != nil {
+			return errors.Wrapf(err, "error handling field %s", name)
+		}
+	}
+
+	if !appendFields {
+		if f.includeUnknownFields {
+			return f.handleUnknownFields(prop, message.GetUnknown(), salts, readablePropertyLengthSuffix, skipSalts)
+		}
+		return nil
+	}
+	return f.appendFieldsLeaf(prop, fieldMap, fieldNames, salts, readablePropertyLengthSuffix, skipSalts)
+}
+
+// handleReflectField flattens the value of a field, which can be a list or a map
+func (f *messageFlattener) handleReflectField(prop Property, fd protoreflect.FieldDescriptor, value protoreflect.Value, salts Salts, readablePropertyLengthSuffix string, outerFieldDescriptor *godescriptor.FieldDescriptorProto, skipSalts bool) error {
+	skipSalts = skipSalts || getNoSaltFrom(outerFieldDescriptor)
+	switch {
+	case fd.IsList() && getMappingKeyFrom(outerFieldDescriptor) != "":
+		return f.handleReflectMappedList(prop, fd, value.List(), salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
+	case fd.IsList():
+		list := value.List()
+		if f.omitZeroLengthLeaves && list.Len() == 0 {
+			return nil
+		}
+
+		// Append length of slice as tree leaf
+		err := f.appendLengthLeaf(prop, list.Len(), salts, readablePropertyLengthSuffix)
+		if err != nil {
+			return err
+		}
+
+		// Handle each element of the slice
+		for i := 0; i < list.Len(); i++ {
+			elemProp := prop.SliceElemProp(FieldNumForSliceLength(i))
+			err := f.handleReflectSingular(elemProp, fd, list.Get(i), salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
+			if err != nil {
+				return errors.Wrapf(err, "error handling slice element %d", i)
+			}
+		}
+		return nil
+	case fd.IsMap():
+		m := value.Map()
+		if f.omitZeroLengthLeaves && m.Len() == 0 {
+			return nil
+		}
+
+		// Append size of map as tree leaf
+		err := f.appendLengthLeaf(prop, m.Len(), salts, readablePropertyLengthSuffix)
+		if err != nil {
+			return err
+		}
+
+		// Handle each value of the map
+		m.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			var elemProp Property
+			elemProp, err = f.reflectMapElemProp(prop, k.Interface(), nil, outerFieldDescriptor)
+			if err != nil {
+				return false
+			}
+			err = f.handleReflectSingular(elemProp, fd.MapValue(), v, salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
+			if err != nil {
+				err = errors.Wrapf(err, "error handling slice element %v", k.Interface())
+				return false
+			}
+			return true
+		})
+		return err
+	}
+	return f.handleReflectSingular(prop, fd, value, salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
+}
+
+// handleReflectMappedList flattens a repeated message field with the mapping_key option like a map keyed by the
+// mapping key field of the elements. If the elements only have one other field besides the key and the salts, the
+// value of that field is used as the value of the map element.
+func (f *messageFlattener) handleReflectMappedList(prop Property, fd protoreflect.FieldDescriptor, list protoreflect.List, salts Salts, readablePropertyLengthSuffix string, outerFieldDescriptor *godescriptor.FieldDescriptorProto, skipSalts bool) error {
+	mappingKey := getMappingKeyFrom(outerFieldDescriptor)
+	keyLength := getKeyLengthFrom(outerFieldDescriptor)
+	wrapErr := func(err error) error {
+		return errors.Wrapf(err, "failed to convert %s value to map with mapping_key %q", fd.FullName(), mappingKey)
+	}
+	if fd.Message() == nil {
+		return wrapErr(errors.Errorf("%s does not have field %q", fd.Kind(), mappingKey))
+	}
+	elemFields := fd.Message().Fields()
+	var keyFields []protoreflect.FieldDescriptor
+	isKeyField := make(map[protoreflect.FieldDescriptor]struct{})
+	for _, name := range splitMappingKey(mappingKey) {
+		keyField := elemFields.ByName(protoreflect.Name(name))
+		if keyField == nil {
+			return wrapErr(errors.Errorf("%s does not have field %q", fd.Message().FullName(), name))
+		}
+		keyFields = append(keyFields, keyField)
+		isKeyField[keyField] = struct{}{}
+	}
+	keyField := keyFields[0]
+	var valueField protoreflect.FieldDescriptor
+	saltsField := elemFields.ByName("salts")
+	var valueFields []protoreflect.FieldDescriptor
+	for i := 0; i < elemFields.Len(); i++ {
+		field := elemFields.Get(i)
+		if _, ok := isKeyField[field]; !ok && field != saltsField {
+			valueFields = append(valueFields, field)
+		}
+	}
+	if len(valueFields) == 1 {
+		valueField = valueFields[0]
+	}
+
+	// later elements with the same key replace earlier ones
+	type entry struct {
+		key   interface{}
+		value protoreflect.Value
+		unset bool
+	}
+	var entries []entry
+	index := make(map[string]int)
+	for i := 0; i < list.Len(); i++ {
+		elem := list.Get(i).Message()
+		key := elem.Get(keyField).Interface()
+		switch k := key.(type) {
+		case []byte:
+			if keyLength != 0 && uint64(len(k)) != keyLength && len(keyFields) == 1 {
+				return wrapErr(errors.Errorf("could not use %x as mapping_key - does not have length %d", k, keyLength))
+			}
+		case protoreflect.EnumNumber:
+			key = int32(k)
+		}
+		id := fmt.Sprint(key)
+		if len(keyFields) > 1 {
+			parts := make([]interface{}, len(keyFields))
+			for j, keyField := range keyFields {
+				parts[j] = elem.Get(keyField).Interface()
+				if number, ok := parts[j].(protoreflect.EnumNumber); ok {
+					parts[j] = int32(number)
+				}
+			}
+			key = parts
+			id = fmt.Sprintf("%#v", parts)
+		}
+		e := entry{key: key, value: list.Get(i)}
+		if valueField != nil {
+			e.value = elem.Get(valueField)
+			// unset proto2 fields don't add a leaf
+			e.unset = valueField.HasPresence() && valueField.Message() == nil && !elem.Has(valueField)
+		}
+
+		if j, ok := index[id]; ok {
+			entries[j] = e
+			continue
+		}
+		index[id] = len(entries)
+		entries = append(entries, e)
+	}
+
+	if f.omitZeroLengthLeaves && len(entries) == 0 {
+		return nil
+	}
+	err := f.appendLengthLeaf(prop, len(entries), salts, readablePropertyLengthSuffix)
+	if err != nil {
+		return err
+	}
+
+	valueFd := fd
+	if valueField != nil {
+		valueFd = valueField
+	}
+	for _, e := range entries {
+		enum := keyField.Enum()
+		if len(keyFields) > 1 {
+			enum = nil
+		}
+		elemProp, err := f.reflectMapElemProp(prop, e.key, enum, outerFieldDescriptor)
+		if err != nil {
+			return err
+		}
+		if e.unset {
+			continue
+		}
+		err = f.handleReflectSingular(elemProp, valueFd, e.value, salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
+		if err != nil {
+			return errors.Wrapf(err, "error handling slice element %v", e.key)
+		}
+	}
+	return nil
+}
+
+// reflectMapElemProp returns the property of a map element. The name of the enum value is used as readable name of
+// enum keys if EnumAsString is set.
+func (f *messageFlattener) reflectMapElemProp(prop Property, key interface{}, enum protoreflect.EnumDescriptor, outerFieldDescriptor *godescriptor.FieldDescriptorProto) (Property, error) {
+	keyLength := getKeyLengthFrom(outerFieldDescriptor)
+	if keyLength == 0 {
+		keyLength = fetchLengthFromInterface(reflect.ValueOf(key))
+	}
+	elemProp, err := prop.MapElemProp(key, keyLength)
+	if err != nil {
+		return Property{}, errors.Wrapf(err, "failed to create elem prop for %q", key)
+	}
+	if enum != nil && f.enumAsString {
+		number := protoreflect.EnumNumber(key.(int32))
+		value := enum.Values().ByNumber(number)
+		if value == nil {
+			return Property{}, errors.Errorf("enum value %d is not defined in %s", number, enum.FullName())
+		}
+		elemProp.Text = string(value.Name())
+	}
+	return elemProp, nil
+}
+
+// handleReflectSingular flattens a single value of the given field, i.e. the value of a singular field or an element
+// of a list or map field
+func (f *messageFlattener) handleReflectSingular(prop Property, fd protoreflect.FieldDescriptor, value protoreflect.Value, salts Salts, readablePropertyLengthSuffix string, outerFieldDescriptor *godescriptor.FieldDescriptorProto, skipSalts bool) error {
+	if fd.Message() != nil {
+		message := value.Message()
+		name := fd.Message().FullName()
+		if encode, ok := wellKnownEncoders[string(name)]; ok {
+			if !message.IsValid() {
+				return nil
+			}
+			generated, err := reflectGenerated(message)
+			if err != nil {
+				return err
+			}
+			return f.appendEncodedValue(prop, encode, generated, salts, readablePropertyLengthSuffix, skipSalts)
+		}
+		if _, ok := wrapperMessageNames[name]; ok {
+			// well-known wrapper types are flattened as their inner scalar under the property of the field
+			if !message.IsValid() {
+				return nil
+			}
+			inner := fd.Message().Fields().ByName("value")
+			return f.handleReflectSingular(prop, inner, message.Get(inner), salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
+		}
+
+		switch {
+		case name == timestampMessageName:
+			// timestamps are salted regardless of the no_salt option
+			return f.appendReflectValue(prop, reflectTimestamp(message), salts, readablePropertyLengthSuffix, outerFieldDescriptor, false)
+		case !message.IsValid():
+			// unset messages don't add a leaf
+			return nil
+		case name == anyMessageName && f.anyResolver != nil:
+			typeURL := message.Get(fd.Message().Fields().ByName("type_url")).String()
+			resolved, err := f.anyResolver.Resolve(typeURL)
+			if err != nil {
+				return errors.Wrapf(err, "failed to resolve %q", typeURL)
+			}
+			err = proto.Unmarshal(message.Get(fd.Message().Fields().ByName("value")).Bytes(), resolved)
+			if err != nil {
+				return errors.Wrapf(err, "failed to unpack %q", typeURL)
+			}
+			return f.handleReflectMessage(prop, proto.MessageReflect(resolved), salts, readablePropertyLengthSuffix, nil, skipSalts)
+		}
+		return f.handleReflectMessage(prop, message, salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts)
+	}
+
+	scalar, err := f.reflectScalar(fd, value)
+	if err != nil {
+		return err
+	}
+	// bytes are salted regardless of the no_salt option
+	return f.appendReflectValue(prop, scalar, salts, readablePropertyLengthSuffix, outerFieldDescriptor, skipSalts && fd.Kind() != protoreflect.BytesKind)
+}
+
+// appendReflectValue adds the leaf of a scalar value, padded if the field has the field_length option
+func (f *messageFlattener) appendReflectValue(prop Property, value interface{}, salts Salts, readablePropertyLengthSuffix string, outerFieldDescriptor *godescriptor.FieldDescriptorProto, skipSalts bool) (err error) {
+	var valueBytesArray []byte
+	if outerFieldDescriptor != nil {
+		var extVal interface{}
+		extVal, err = proto.GetExtension(outerFieldDescriptor.Options, proofspb.E_FieldLength)
+		if err == nil {
+			fixedFieldLength := *(extVal.(*uint64))
+			valueBytesArray, err = f.valueToPaddingBytesArray(value, int(fixedFieldLength))
+		} else {
+			valueBytesArray, err = f.valueToBytesArray(value)
+		}
+	} else {
+		valueBytesArray, err = f.valueToBytesArray(value)
+	}
+	if err != nil {
+		return err
+	}
+
+	var salt []byte
+	if !skipSalts {
+		salt, err = salts(prop.CompactName())
+		if err != nil {
+			return err
+		}
+	}
+	return f.appendLeaf(prop, valueBytesArray, salt, readablePropertyLengthSuffix, []byte{}, false)
+}
+
+// reflectAppendedValue returns the value of a field of a message with the append_fields option
+func (f *messageFlattener) reflectAppendedValue(prop Property, fd protoreflect.FieldDescriptor, value protoreflect.Value, salts Salts, readablePropertyLengthSuffix string, innerFieldDescriptor *godescriptor.FieldDescriptorProto) ([]byte, error) {
+	if fd.IsList() || fd.IsMap() {
+		return nil, errors.Errorf("Got unsupported value of type %s", fd.Kind())
+	}
+
+	var scalar interface{}
+	if fd.Message() != nil {
+		message := value.Message()
+		switch {
+		case fd.Message().FullName() == timestampMessageName:
+			scalar = reflectTimestamp(message)
+		case getAppendFieldsFrom(innerFieldDescriptor):
+			if !message.IsValid() {
+				return nil, nil
+			}
+			return f.nestedAppendedValue(func(nested *messageFlattener) error {
+				return nested.handleReflectMessage(prop, message, salts, readablePropertyLengthSuffix, innerFieldDescriptor, true)
+			})
+		default:
+			return nil, errors.Errorf("Got unsupported value of type %s", fd.Message().FullName())
+		}
+	} else {
+		var err error
+		scalar, err = f.reflectScalar(fd, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fixedLength := getKeyLengthFrom(innerFieldDescriptor)
+	if fixedLength == 0 {
+		return f.valueToBytesArray(scalar)
+	}
+	return f.valueToPaddingBytesArray(scalar, int(fixedLength))
+}
+
+// reflectScalar returns the value of a scalar field as the Go type used by generated messages. Enums are returned as
+// int64, which is how the values of generated enum types are encoded.
+func (f *messageFlattener) reflectScalar(fd protoreflect.FieldDescriptor, value protoreflect.Value) (interface{}, error) {
+	if fd.Enum() != nil {
+		number := value.Enum()
+		if f.strictEnums && fd.Enum().Values().ByNumber(number) == nil {
+			return nil, errors.Errorf("enum value %d is not defined in %s", number, fd.Enum().FullName())
+		}
+		return int64(number), nil
+	}
+	return value.Interface(), nil
+}
+
+// reflectTimestamp converts a google.protobuf.Timestamp message, nil is returned for an unset message
+func reflectTimestamp(message protoreflect.Message) *timestamp.Timestamp {
+	if !message.IsValid() {
+		return nil
+	}
+	fields := message.Descriptor().Fields()
+	return &timestamp.Timestamp{
+		Seconds: message.Get(fields.ByName("seconds")).Int(),
+		Nanos:   int32(message.Get(fields.ByName("nanos")).Int()),
+	}
+}
+
+// reflectGenerated returns the message as its generated Go type if one is registered, so WellKnownEncoders get the
+// same types from AddLeavesFromMessage as from AddLeavesFromDocument
+func reflectGenerated(message protoreflect.Message) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(message.Descriptor().FullName())
+	if err != nil || mt == message.Type() {
+		return proto.MessageV1(message.Interface()), nil
+	}
+	b, err := proto.Marshal(proto.MessageV1(message.Interface()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s", message.Descriptor().FullName())
+	}
+	generated := proto.MessageV1(mt.New().Interface())
+	err = proto.Unmarshal(b, generated)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", message.Descriptor().FullName())
+	}
+	return generated, nil
+}
+
+// newReflectSaltCollector returns a saltCollector for the salts field of a message of the protoreflect API
+func newReflectSaltCollector(message protoreflect.Message) (*saltCollector, error) {
+	fd := message.Descriptor().Fields().ByName("salts")
+	if fd == nil || !fd.IsList() || fd.Message() == nil || fd.Message().FullName() != saltMessageName {
+		return nil, errors.New("Cannot find salts field in message")
+	}
+	compactField := fd.Message().Fields().ByName("compact")
+	valueField := fd.Message().Fields().ByName("value")
+
+	list := message.Get(fd).List()
+	salts := make([]*proofspb.Salt, list.Len())
+	for i := range salts {
+		salt := list.Get(i).Message()
+		salts[i] = &proofspb.Salt{
+			Compact: salt.Get(compactField).Bytes(),
+			Value:   salt.Get(valueField).Bytes(),
+		}
+	}
+	return newSaltCollectorWith(salts, func(salts []*proofspb.Salt) error {
+		list := message.Mutable(fd).List()
+		list.Truncate(0)
+		for _, salt := range salts {
+			elem := list.NewElement()
+			elem.Message().Set(compactField, protoreflect.ValueOfBytes(salt.Compact))
+			elem.Message().Set(valueField, protoreflect.ValueOfBytes(salt.Value))
+			list.Append(elem)
+		}
+		return nil
+	}), nil
+}
@@ -0,0 +1,147 @@
+package proofs
+
+import (
+	"strings"
+	"testing"
+
+	documentspb "github.com/centrifuge/precise-proofs/examples/documents"
+	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+	protov2 "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// toDynamic copies a generated message to a dynamicpb message, which only supports the protoreflect API
+func toDynamic(t *testing.T, message proto.Message) *dynamicpb.Message {
+	m := proto.MessageV2(message)
+	b, err := protov2.Marshal(m)
+	assert.NoError(t, err)
+	dynamic := dynamicpb.NewMessage(m.ProtoReflect().Descriptor())
+	assert.NoError(t, protov2.Unmarshal(b, dynamic))
+	return dynamic
+}
+
+func TestFlattenReflect_MatchesLegacy(t *testing.T) {
+	proto2Doc := &documentspb.Proto2Document{ValueA: proto.String("foo"), ValueC: proto.String("bar")}
+	tests := []struct {
+		name string
+		doc  proto.Message
+		opts TreeOptions
+	}{
+		{"long", &documentspb.LongDocumentExample, TreeOptions{}},
+		{"example", &documentspb.ExampleDocument{
+			ValueA:          "Example",
+			Value1:          1,
+			ValueBytes1:     []byte("bytes"),
+			ValueCamelCased: []byte("camel"),
+			ValueIgnored:    []byte("ignored"),
+			ValueNotHashed:  hashBytes(sha256Hash, []byte("hashed")),
+			EnumType:        documentspb.Enum_type_two,
+			ValueBool:       true,
+			Name:            &documentspb.Name{First: "john", Last: "doe"},
+			PaddingA:        "padded",
+		}, TreeOptions{}},
+		{"all field types", documentspb.NewAllFieldTypes(), TreeOptions{}},
+		{"unset timestamp", &documentspb.AllFieldTypes{StringValue: "foo"}, TreeOptions{}},
+		{"repeated", &documentspb.ExampleFilledRepeatedDocument, TreeOptions{}},
+		{"two level repeated", &documentspb.ExampleFilledTwoLevelRepeatedDocument, TreeOptions{}},
+		{"nested repeated", &documentspb.ExampleFilledNestedRepeatedDocument, TreeOptions{}},
+		{"maps", &documentspb.ExampleSimpleMapDocument, TreeOptions{}},
+		{"nested map", &documentspb.NestedMap{Value: map[int32]*documentspb.SimpleMap{1: {Value: map[int32]string{2: "two"}}}}, TreeOptions{}},
+		{"oneof", &documentspb.ExampleOneofSampleDocument, TreeOptions{}},
+		{"oneof message", &documentspb.OneofSample{OneofBlock: &documentspb.OneofSample_ValueD{ValueD: &documentspb.SimpleItem{ValueA: "foo"}}}, TreeOptions{}},
+		{"mapping key", &documentspb.Entries{Entries: []*documentspb.Entry{
+			{EntryKey: "a", ValueA: "foo", ValueB: []byte("bar"), ValueC: 1},
+			{EntryKey: "b", ValueA: "baz"},
+		}}, TreeOptions{}},
+		{"single value mapping key", &documentspb.SimpleEntries{Entries: []*documentspb.SimpleEntry{
+			{EntryKey: "a", EntryValue: "foo"},
+			{EntryKey: "a", EntryValue: "bar"},
+			{EntryKey: "b", EntryValue: "baz"},
+		}}, TreeOptions{}},
+		{"composite mapping key", &documentspb.CompositeKeyDocument{Entries: []*documentspb.CompositeKeyEntry{
+			{Country: "DE", Year: 2020, Value: "a"},
+			{Country: "FR", Year: 2020, Value: "b"},
+		}}, TreeOptions{}},
+		{"bytes mapping key", &documentspb.BytesKeyEntries{Entries: []*documentspb.BytesKeyEntry{
+			{Address: make([]byte, 20), Value: "foo"},
+		}}, TreeOptions{}},
+		{"enum mapping key", &documentspb.EnumKeyDocument{Entries: []*documentspb.EnumKeyEntry{
+			{Type: documentspb.Enum_type_two, Value: "foo"},
+		}}, TreeOptions{EnumAsString: true}},
+		{"hashed slice", &documentspb.RepeatedHashedFieldDocument{Hashes: [][]byte{
+			hashBytes(sha256Hash, []byte("a")), hashBytes(sha256Hash, []byte("b")),
+		}}, TreeOptions{}},
+		{"append fields", &documentspb.AppendFieldDocument{
+			Name:         &documentspb.Name{First: "john", Last: "doe"},
+			Names:        []*documentspb.Name{{First: "jane"}},
+			PhoneNumbers: []*documentspb.PhoneNumber{{Type: "work", Countrycode: "49", Number: "123"}},
+		}, TreeOptions{}},
+		{"nested append fields", &documentspb.NestedAppendDocument{Person: &documentspb.NestedAppendInner{
+			First: "john", Name: &documentspb.Name{First: "jane", Last: "doe"}, Age: 42,
+		}}, TreeOptions{}},
+		{"append fields padding", &documentspb.AppendFieldPaddingDocument{Names: []*documentspb.NamePadded{{First: "john", Age: 42}}}, TreeOptions{}},
+		{"no salt", &documentspb.NoSaltDocument{ValueNoSalt: "foo", ValueSalt: "bar", Name: &documentspb.Name{First: "john"}}, TreeOptions{}},
+		{"wrappers", &documentspb.WrapperDocument{Name: &wrappers.StringValue{Value: "foo"}, Active: &wrappers.BoolValue{Value: true}}, TreeOptions{}},
+		{"proto2", proto2Doc, TreeOptions{}},
+		{"integers", &documentspb.Integers{ValueA: -1, ValueB: 2, ValueC: 3, ValueD: 4, ValueE: -5, ValueF: 6, ValueG: 7, ValueH: 8, ValueI: -9, ValueJ: 10}, TreeOptions{}},
+		{"json names", &documentspb.ExampleDocument{ValueBytes1: []byte("foo"), Name: &documentspb.Name{First: "john"}}, TreeOptions{UseJSONNames: true}},
+		{"normalized names", &documentspb.ExampleDocument{ValueCamelCased: []byte("foo"), Name: &documentspb.Name{First: "john"}}, TreeOptions{NormalizeNameCase: strings.ToLower}},
+		{"options", &documentspb.ExampleFilledNestedRepeatedDocument, TreeOptions{
+			CompactProperties: true,
+			TypeTagValues:     true,
+			EVMEncoding:       true,
+			ParentPrefix:      NewProperty("prefix", 42),
+			ExcludeFields:     []string{"prefix.valueD.valueB"},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := test.opts
+			opts.Hash = sha256Hash
+			opts.Salts = NewSaltForTest
+
+			legacy, err := NewDocumentTree(opts)
+			assert.NoError(t, err)
+			assert.NoError(t, legacy.AddLeavesFromDocument(test.doc))
+			assert.NoError(t, legacy.Generate())
+
+			doctree, err := NewDocumentTree(opts)
+			assert.NoError(t, err)
+			assert.NoError(t, doctree.AddLeavesFromMessage(toDynamic(t, test.doc)))
+			assert.NoError(t, doctree.Generate())
+
+			assert.Equal(t, legacy.PropertyOrder(), doctree.PropertyOrder())
+			assert.Equal(t, legacy.RootHash(), doctree.RootHash())
+		})
+	}
+}
+
+func TestFlattenReflect_Salts(t *testing.T) {
+	dynamic := toDynamic(t, &documentspb.ExampleFilledNestedRepeatedDocument)
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromMessage(dynamic))
+	assert.NoError(t, doctree.Generate())
+
+	// the generated salts were written to the dynamic message
+	doc := new(documentspb.NestedRepeatedDocument)
+	b, err := protov2.Marshal(dynamic)
+	assert.NoError(t, err)
+	assert.NoError(t, protov2.Unmarshal(b, doc))
+	assert.Len(t, doc.Salts, len(doctree.GetLeaves()))
+
+	legacy, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, legacy.AddLeavesFromDocument(doc))
+	assert.NoError(t, legacy.Generate())
+	assert.Equal(t, legacy.RootHash(), doctree.RootHash())
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromMessage(&proofspb.MerkleHash{})
+	assert.EqualError(t, err, "Cannot find salts field in message")
+}
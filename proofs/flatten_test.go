@@ -1,10 +1,16 @@
 package proofs
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"encoding/json"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/centrifuge/precise-proofs/examples/documents"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -463,6 +469,43 @@ func TestFlatten_AppendFields(t *testing.T) {
 	assert.NotNil(t, leaves[5].Salt)
 }
 
+func TestFlattenMessage_Proto2(t *testing.T) {
+	message := &documentspb.Proto2Document{
+		ValueA: proto.String("foo"),
+		ValueC: proto.String(""),
+		ValueD: proto.Bool(false),
+	}
+	leaves, err := FlattenMessage(message, NewSaltForTest, DefaultReadablePropertyLengthSuffix, sha256Hash, false, Empty, false)
+	assert.NoError(t, err)
+
+	// set fields are added even if they hold the zero value, unset fields are skipped
+	var names []string
+	for _, leaf := range leaves {
+		names = append(names, leaf.Property.ReadableName())
+	}
+	assert.Equal(t, []string{"value_a", "value_c", "value_d"}, names)
+	assert.Equal(t, []byte("foo"), leaves[0].Value)
+	assert.Equal(t, []byte{}, leaves[1].Value)
+	assert.Equal(t, []byte{0}, leaves[2].Value)
+
+	message.ValueB = proto.Int64(0)
+	leaves, err = FlattenMessage(message, NewSaltForTest, DefaultReadablePropertyLengthSuffix, sha256Hash, false, Empty, false)
+	assert.NoError(t, err)
+	assert.Len(t, leaves, 4)
+	assert.Equal(t, "value_b", leaves[1].Property.ReadableName())
+	assert.Equal(t, make([]byte, 8), leaves[1].Value)
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(message))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("value_a")
+	assert.NoError(t, err)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
 func TestFlatten_AppendField_Failure(t *testing.T) {
 	doc := &documentspb.UnsupportedAppendDocument{
 		Name: &documentspb.Name{
@@ -561,3 +604,89 @@ func TestFlatten_FieldNoSalt(t *testing.T) {
 	assert.Equal(t, leaves[1].Value, []byte("doe"))
 	assert.Nil(t, leaves[1].Salt)
 }
+
+func TestEncodeValue(t *testing.T) {
+	f := &messageFlattener{}
+	v, err := EncodeValue("foo", "string")
+	assert.NoError(t, err)
+	expected, _ := f.valueToBytesArray("foo")
+	assert.Equal(t, expected, v)
+
+	expected, _ = f.valueToBytesArray(int64(-42))
+	for _, typed := range []interface{}{int64(-42), -42, float64(-42), json.Number("-42"), "-42"} {
+		v, err = EncodeValue(typed, "int64")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, v)
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, documentspb.ExampleTimeString)
+	assert.NoError(t, err)
+	pt, _ := ptypes.TimestampProto(ts)
+	expected, _ = f.valueToBytesArray(pt)
+	for _, typed := range []interface{}{ts, pt, documentspb.ExampleTimeString, ts.Unix()} {
+		v, err = EncodeValue(typed, "timestamp")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, v)
+	}
+
+	_, err = EncodeValue(1, "string")
+	assert.EqualError(t, err, "expected a string value, got int")
+	_, err = EncodeValue("foo", "int64")
+	assert.Error(t, err)
+	_, err = EncodeValue("foo", "timestamp")
+	assert.Error(t, err)
+	_, err = EncodeValue("foo", "bytes")
+	assert.EqualError(t, err, "unsupported value type \"bytes\"")
+}
+
+func TestToEVMWord(t *testing.T) {
+	word, err := toEVMWord(int64(-1))
+	assert.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0xff}, 32), word)
+
+	word, err = toEVMWord(int32(-2))
+	assert.NoError(t, err)
+	assert.Equal(t, append(bytes.Repeat([]byte{0xff}, 31), 0xfe), word)
+
+	word, err = toEVMWord(uint64(math.MaxUint64))
+	assert.NoError(t, err)
+	assert.Equal(t, append(make([]byte, 24), bytes.Repeat([]byte{0xff}, 8)...), word)
+
+	word, err = toEVMWord(int8(5))
+	assert.NoError(t, err)
+	assert.Equal(t, append(make([]byte, 31), 5), word)
+
+	_, err = toEVMWord("foo")
+	assert.EqualError(t, err, "Got unsupported value of type string")
+}
+
+func TestFlattenMessage_MapOfLists(t *testing.T) {
+	message := &documentspb.ListMapDocument{
+		Lists: map[string]*documentspb.StringList{
+			"a": {Values: []string{"x", "y"}},
+			"b": {},
+		},
+	}
+
+	leaves, err := FlattenMessage(message, NewSaltForTest, DefaultReadablePropertyLengthSuffix, sha256Hash, false, Empty, false)
+	assert.NoError(t, err)
+	var names []string
+	for _, leaf := range leaves {
+		names = append(names, leaf.Property.ReadableName())
+	}
+	assert.Equal(t, []string{
+		"lists.length",
+		"lists[a].values.length",
+		"lists[a].values[0]",
+		"lists[a].values[1]",
+		"lists[b].values.length",
+	}, names)
+	assert.Equal(t, []byte("y"), leaves[3].Value)
+
+	listsProp := Empty.FieldProp("lists", 1)
+	elemProp, err := listsProp.MapElemProp("a", 8)
+	assert.NoError(t, err)
+	assert.Equal(t, elemProp.FieldProp("values", 1).SliceElemProp(1), leaves[3].Property)
+	assert.Equal(t, []byte{0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 'a', 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1}, leaves[3].Property.CompactName())
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0}, leaves[4].Value)
+}
@@ -0,0 +1,420 @@
+package proofs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"math/bits"
+
+	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
+	"github.com/xsleonard/go-merkle"
+)
+
+// NodeHasher calculates the hash of an inner node of the tree from its two children. HashTwoValues, the default,
+// hashes the concatenation of both children.
+type NodeHasher func(a, b []byte, hashFunc hash.Hash) []byte
+
+// versionedNodeHasher prepends the scheme version to the left child passed to nodeHasher
+func versionedNodeHasher(version byte, nodeHasher NodeHasher) NodeHasher {
+	return func(a, b []byte, hashFunc hash.Hash) []byte {
+		return nodeHasher(append([]byte{version}, a...), b, hashFunc)
+	}
+}
+
+// unbalancedTree is a merkle tree over any number of leaves. A node without a sibling is promoted to the next level
+// unchanged, so the tree is unbalanced on its right side.
+type unbalancedTree struct {
+	// nodes contains the leaves first, followed by the nodes of each level up to the root
+	nodes             [][]byte
+	leafCount         uint64
+	hashFunc          hash.Hash
+	nodeHasher        NodeHasher
+	enableHashSorting bool
+}
+
+func newUnbalancedTree(hashFunc hash.Hash, nodeHasher NodeHasher, enableHashSorting bool) *unbalancedTree {
+	return &unbalancedTree{hashFunc: hashFunc, nodeHasher: nodeHasher, enableHashSorting: enableHashSorting}
+}
+
+// Generate calculates all nodes of the tree from the given leaf hashes. totalSize is ignored, the tree always has as
+// many leaves as provided.
+func (t *unbalancedTree) Generate(leaves [][]byte, totalSize int) error {
+	if len(leaves) == 0 {
+		return errors.New("Empty tree")
+	}
+
+	_, nodeCount := calculateHeightAndNodeCount(uint64(len(leaves)))
+	// the capacity is allocated upfront, so level keeps pointing into nodes while appending
+	nodes := make([][]byte, 0, nodeCount)
+	nodes = append(nodes, leaves...)
+	level := nodes
+	for len(level) > 1 {
+		start := len(nodes)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				nodes = append(nodes, level[i])
+				continue
+			}
+			nodes = append(nodes, t.hashPair(level[i], level[i+1]))
+		}
+		level = nodes[start:]
+	}
+
+	t.nodes = nodes
+	t.leafCount = uint64(len(leaves))
+	return nil
+}
+
+func (t *unbalancedTree) hashPair(left, right []byte) []byte {
+	// a nil node is treated like a missing one, so the left node is promoted. This keeps the roots of trees with
+	// unset hashed fields compatible with earlier versions.
+	if right == nil {
+		return left
+	}
+	if t.enableHashSorting && bytes.Compare(left, right) > 0 {
+		return t.nodeHasher(right, left, t.hashFunc)
+	}
+	return t.nodeHasher(left, right, t.hashFunc)
+}
+
+// RootHash returns the root of the tree or nil if the tree has not been generated yet
+func (t *unbalancedTree) RootHash() []byte {
+	if t.nodes == nil {
+		return nil
+	}
+	return t.nodes[len(t.nodes)-1]
+}
+
+// allNodes returns the hashes of all nodes, starting with the leaves followed by each level up to the root
+func (t *unbalancedTree) allNodes() [][]byte {
+	return append([][]byte(nil), t.nodes...)
+}
+
+// GetMerkleProof returns the sibling hashes needed to calculate the root from the given leaf
+func (t *unbalancedTree) GetMerkleProof(leafIndex uint) ([]merkle.ProofNode, error) {
+	if t.nodes == nil {
+		return nil, errors.New("Tree is empty")
+	}
+
+	hashNodes, err := CalculateProofNodeList(uint64(leafIndex), t.leafCount)
+	if err != nil {
+		return nil, err
+	}
+
+	proofNodes := make([]merkle.ProofNode, len(hashNodes))
+	for i, n := range hashNodes {
+		proofNodes[i] = merkle.ProofNode{Left: n.Left, Hash: t.nodes[n.Leaf]}
+	}
+	return proofNodes, nil
+}
+
+// consistencyProof returns the hashes proving that the first oldSize leaves of the tree form a tree with the root of
+// an earlier version, following the consistency proofs of RFC 6962. Pairing the nodes of each level and promoting lone
+// nodes results in the same layout as splitting the leaves at the largest power of two like RFC 6962.
+func (t *unbalancedTree) consistencyProof(oldSize int) ([][]byte, error) {
+	if t.nodes == nil {
+		return nil, errors.New("Tree is empty")
+	}
+	if t.enableHashSorting {
+		return nil, errors.New("consistency proofs are not supported for trees with sorted hashes")
+	}
+	if oldSize <= 0 || uint64(oldSize) > t.leafCount {
+		return nil, fmt.Errorf("old size %d is not between 1 and the number of leaves %d", oldSize, t.leafCount)
+	}
+	return t.subProof(oldSize, t.nodes[:t.leafCount], true), nil
+}
+
+// subProof implements SUBPROOF of RFC 6962 for the first m of the given leaves, complete is set as long as the m
+// leaves form a complete subtree whose hash is known to the verifier
+func (t *unbalancedTree) subProof(m int, leaves [][]byte, complete bool) [][]byte {
+	n := len(leaves)
+	if m == n {
+		if complete {
+			return nil
+		}
+		return [][]byte{t.subtreeHash(leaves)}
+	}
+	k := largestPowerOfTwoBelow(n)
+	if m <= k {
+		return append(t.subProof(m, leaves[:k], complete), t.subtreeHash(leaves[k:]))
+	}
+	return append(t.subProof(m-k, leaves[k:], false), t.subtreeHash(leaves[:k]))
+}
+
+// subtreeHash calculates the root of a tree with the given leaves
+func (t *unbalancedTree) subtreeHash(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := largestPowerOfTwoBelow(len(leaves))
+	return t.hashPair(t.subtreeHash(leaves[:k]), t.subtreeHash(leaves[k:]))
+}
+
+// largestPowerOfTwoBelow returns the largest power of two smaller than n, n has to be greater than 1
+func largestPowerOfTwoBelow(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+// verifyConsistency checks a consistency proof between the roots of a tree with oldSize leaves and a tree with
+// newSize leaves as described in RFC 9162, section 2.1.4.2.
+func verifyConsistency(oldRoot, newRoot []byte, proof [][]byte, oldSize, newSize int, hashFunc hash.Hash, nodeHasher NodeHasher) (bool, error) {
+	if oldSize <= 0 || oldSize > newSize {
+		return false, fmt.Errorf("old size %d is not between 1 and the new size %d", oldSize, newSize)
+	}
+	if oldSize == newSize {
+		if len(proof) != 0 {
+			return false, errors.New("proof for trees of the same size has to be empty")
+		}
+		if !bytes.Equal(oldRoot, newRoot) {
+			return false, errors.New("Hash does not match")
+		}
+		return true, nil
+	}
+	if len(proof) == 0 {
+		return false, errors.New("proof is empty")
+	}
+
+	if oldSize&(oldSize-1) == 0 {
+		// the old tree is a complete subtree of the new one
+		proof = append([][]byte{oldRoot}, proof...)
+	}
+	fn, sn := uint64(oldSize-1), uint64(newSize-1)
+	for fn&1 == 1 {
+		fn, sn = fn>>1, sn>>1
+	}
+	fr, sr := proof[0], proof[0]
+	for _, c := range proof[1:] {
+		if sn == 0 {
+			return false, errors.New("proof has too many hashes")
+		}
+		if fn&1 == 1 || fn == sn {
+			fr = nodeHasher(c, fr, hashFunc)
+			sr = nodeHasher(c, sr, hashFunc)
+			if fn&1 == 0 {
+				for fn&1 == 0 && fn != 0 {
+					fn, sn = fn>>1, sn>>1
+				}
+			}
+		} else {
+			sr = nodeHasher(sr, c, hashFunc)
+		}
+		fn, sn = fn>>1, sn>>1
+	}
+	if sn != 0 {
+		return false, errors.New("proof has too few hashes")
+	}
+	if !bytes.Equal(fr, oldRoot) || !bytes.Equal(sr, newRoot) {
+		return false, errors.New("Hash does not match")
+	}
+	return true, nil
+}
+
+// sparseTree is a merkle tree with a fixed number of leaves, a power of two. Leaves that are not provided are filled
+// with an empty hash. Their subtrees are never calculated, the root of an empty subtree only depends on its height.
+type sparseTree struct {
+	// levels contains the non empty nodes of each level, starting with the leaves
+	levels [][][]byte
+	// emptyHashes contains the root of an empty subtree for each level, starting with the empty leaf
+	emptyHashes [][]byte
+	emptyHash   []byte
+	hashFunc    hash.Hash
+	nodeHasher  NodeHasher
+}
+
+func newSparseTree(emptyHash []byte, hashFunc hash.Hash, nodeHasher NodeHasher) *sparseTree {
+	return &sparseTree{emptyHash: emptyHash, hashFunc: hashFunc, nodeHasher: nodeHasher}
+}
+
+// Generate calculates all non empty nodes of a tree with totalSize leaves from the given leaf hashes
+func (t *sparseTree) Generate(leaves [][]byte, totalSize int) error {
+	if t.levels != nil {
+		return errors.New("SMT tree already filled")
+	}
+	if totalSize <= 0 || totalSize&(totalSize-1) != 0 {
+		return errors.New("Leaves number of SMT tree should be power of 2")
+	}
+	if len(leaves) > totalSize {
+		return errors.New("NonEmptyLeaves is bigger than totalSize")
+	}
+
+	depth := bits.TrailingZeros(uint(totalSize))
+	emptyHashes := [][]byte{t.emptyHash}
+	for i := 0; i < depth; i++ {
+		emptyHashes = append(emptyHashes, t.nodeHasher(emptyHashes[i], emptyHashes[i], t.hashFunc))
+	}
+
+	level := leaves
+	levels := [][][]byte{leaves}
+	for d := 0; d < depth; d++ {
+		next := make([][]byte, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			right := emptyHashes[d]
+			if i+1 < len(level) {
+				right = level[i+1]
+			}
+			next = append(next, t.nodeHasher(level[i], right, t.hashFunc))
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	t.levels = levels
+	t.emptyHashes = emptyHashes
+	return nil
+}
+
+// RootHash returns the root of the tree or nil if the tree has not been generated yet
+func (t *sparseTree) RootHash() []byte {
+	if t.levels == nil {
+		return nil
+	}
+	return t.nodeAt(len(t.levels)-1, 0)
+}
+
+// nodeAt returns the hash of the node at the given position, which is the root of an empty subtree if no leaf
+// below it was provided
+func (t *sparseTree) nodeAt(level int, index uint64) []byte {
+	if index < uint64(len(t.levels[level])) {
+		return t.levels[level][index]
+	}
+	return t.emptyHashes[level]
+}
+
+// allNodes returns the hashes of the non empty nodes, starting with the leaves followed by each level up to the root.
+// Nodes that only have empty leaves below them are left out.
+func (t *sparseTree) allNodes() [][]byte {
+	var nodes [][]byte
+	for _, level := range t.levels {
+		nodes = append(nodes, level...)
+	}
+	return nodes
+}
+
+// GetMerkleProof returns the sibling hashes needed to calculate the root from the given leaf. All proofs have the
+// same length, the depth of the tree.
+func (t *sparseTree) GetMerkleProof(leafIndex uint) ([]merkle.ProofNode, error) {
+	if t.levels == nil {
+		return nil, errors.New("SMT tree is not filled")
+	}
+	depth := len(t.levels) - 1
+	if uint64(leafIndex) >= uint64(1)<<uint(depth) {
+		return nil, errors.New("node index is too big for node count")
+	}
+
+	proofNodes := make([]merkle.ProofNode, depth)
+	index := uint64(leafIndex)
+	for level := 0; level < depth; level++ {
+		proofNodes[level] = merkle.ProofNode{Left: index%2 == 1, Hash: t.nodeAt(level, index^1)}
+		index = index / 2
+	}
+	return proofNodes, nil
+}
+
+// karyTree is a merkle tree whose inner nodes have up to arity children. Like unbalancedTree, the last node of a
+// level is promoted unchanged if it has no siblings. The parent of a group of nodes is the hash of their
+// concatenation, which matches HashTwoValues for groups of two.
+type karyTree struct {
+	// levels contains the nodes of each level, starting with the leaves
+	levels   [][][]byte
+	arity    int
+	hashFunc hash.Hash
+}
+
+func newKaryTree(arity int, hashFunc hash.Hash) *karyTree {
+	return &karyTree{arity: arity, hashFunc: hashFunc}
+}
+
+// Generate calculates all nodes of the tree from the given leaf hashes. totalSize is ignored, the tree always has as
+// many leaves as provided.
+func (t *karyTree) Generate(leaves [][]byte, totalSize int) error {
+	if len(leaves) == 0 {
+		return errors.New("Empty tree")
+	}
+
+	levels := [][][]byte{leaves}
+	level := leaves
+	for len(level) > 1 {
+		next := make([][]byte, 0, (len(level)+t.arity-1)/t.arity)
+		for i := 0; i < len(level); i += t.arity {
+			end := i + t.arity
+			if end > len(level) {
+				end = len(level)
+			}
+			if end-i == 1 {
+				next = append(next, level[i])
+				continue
+			}
+			var group []byte
+			for _, node := range level[i:end] {
+				group = append(group, node...)
+			}
+			next = append(next, hashBytes(t.hashFunc, group))
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	t.levels = levels
+	return nil
+}
+
+// RootHash returns the root of the tree or nil if the tree has not been generated yet
+func (t *karyTree) RootHash() []byte {
+	if t.levels == nil {
+		return nil
+	}
+	return t.levels[len(t.levels)-1][0]
+}
+
+// allNodes returns the hashes of all nodes, starting with the leaves followed by each level up to the root
+func (t *karyTree) allNodes() [][]byte {
+	var nodes [][]byte
+	for _, level := range t.levels {
+		nodes = append(nodes, level...)
+	}
+	return nodes
+}
+
+// GetMerkleProof is not supported, as a single sibling per level can't describe the proofs of k-ary trees. Use
+// proofSteps instead.
+func (t *karyTree) GetMerkleProof(leafIndex uint) ([]merkle.ProofNode, error) {
+	return nil, errors.New("proofs of k-ary trees have more than one sibling per level")
+}
+
+// proofSteps returns one step per level that isn't promoted, holding the concatenated siblings left and right of the
+// node in its group
+func (t *karyTree) proofSteps(leafIndex uint64) ([]*proofspb.MerkleHash, error) {
+	if t.levels == nil {
+		return nil, errors.New("Tree is empty")
+	}
+	if leafIndex >= uint64(len(t.levels[0])) {
+		return nil, errors.New("node index is too big for node count")
+	}
+
+	var steps []*proofspb.MerkleHash
+	index := int(leafIndex)
+	for _, level := range t.levels[:len(t.levels)-1] {
+		start := index - index%t.arity
+		end := start + t.arity
+		if end > len(level) {
+			end = len(level)
+		}
+		if end-start > 1 {
+			step := &proofspb.MerkleHash{}
+			for _, node := range level[start:index] {
+				step.Left = append(step.Left, node...)
+			}
+			for _, node := range level[index+1 : end] {
+				step.Right = append(step.Right, node...)
+			}
+			steps = append(steps, step)
+		}
+		index /= t.arity
+	}
+	return steps, nil
+}
@@ -0,0 +1,133 @@
+package proofs
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xsleonard/go-merkle"
+)
+
+func testLeafHashes(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		h := sha256.Sum256([]byte{byte(i), byte(i >> 8)})
+		leaves[i] = h[:]
+	}
+	return leaves
+}
+
+// TestUnbalancedTree_GoMerkle ensures the roots and proofs match the ones of github.com/xsleonard/go-merkle
+func TestUnbalancedTree_GoMerkle(t *testing.T) {
+	for _, sorted := range []bool{false, true} {
+		for n := 1; n <= 70; n++ {
+			leaves := testLeafHashes(n)
+			if n > 5 {
+				// unset hashed fields result in nil leaves
+				leaves[5] = nil
+			}
+			expected := merkle.NewTree(sha256.New())
+			if sorted {
+				expected = merkle.NewTreeWithHashSortingEnable(sha256.New())
+			}
+			assert.NoError(t, expected.Generate(leaves, 0))
+			tree := newUnbalancedTree(sha256.New(), HashTwoValues, sorted)
+			assert.NoError(t, tree.Generate(leaves, 0))
+			assert.Equal(t, expected.RootHash(), tree.RootHash(), "%d leaves", n)
+
+			for i := 0; i < n; i++ {
+				expectedProof, err := expected.GetMerkleProof(uint(i))
+				assert.NoError(t, err)
+				proof, err := tree.GetMerkleProof(uint(i))
+				assert.NoError(t, err)
+				assert.Equal(t, expectedProof, proof, "leaf %d of %d", i, n)
+			}
+			_, err := tree.GetMerkleProof(uint(n))
+			assert.EqualError(t, err, "node index is too big for node count")
+		}
+	}
+
+	tree := newUnbalancedTree(sha256.New(), HashTwoValues, false)
+	assert.Nil(t, tree.RootHash())
+	_, err := tree.GetMerkleProof(0)
+	assert.EqualError(t, err, "Tree is empty")
+	assert.EqualError(t, tree.Generate(nil, 0), "Empty tree")
+}
+
+// TestSparseTree_GoMerkle ensures the roots and proofs match the ones of github.com/xsleonard/go-merkle
+func TestSparseTree_GoMerkle(t *testing.T) {
+	emptyHash := sha256.Sum256([]byte{})
+	for depth := 1; depth <= 6; depth++ {
+		size := 1 << uint(depth)
+		for n := 0; n <= size; n++ {
+			leaves := testLeafHashes(n)
+			expected := merkle.NewSMT(emptyHash[:], sha256.New())
+			assert.NoError(t, expected.Generate(leaves, size))
+			tree := newSparseTree(emptyHash[:], sha256.New(), HashTwoValues)
+			assert.NoError(t, tree.Generate(leaves, size))
+			assert.Equal(t, expected.RootHash(), tree.RootHash(), "%d of %d leaves", n, size)
+
+			for i := 0; i < n; i++ {
+				expectedProof, err := expected.GetMerkleProof(uint(i))
+				assert.NoError(t, err)
+				proof, err := tree.GetMerkleProof(uint(i))
+				assert.NoError(t, err)
+				assert.Equal(t, expectedProof, proof, "leaf %d of %d", i, n)
+			}
+		}
+	}
+
+	tree := newSparseTree(emptyHash[:], sha256.New(), HashTwoValues)
+	assert.Nil(t, tree.RootHash())
+	_, err := tree.GetMerkleProof(0)
+	assert.EqualError(t, err, "SMT tree is not filled")
+	assert.EqualError(t, tree.Generate(testLeafHashes(3), 3), "Leaves number of SMT tree should be power of 2")
+	assert.EqualError(t, tree.Generate(testLeafHashes(5), 4), "NonEmptyLeaves is bigger than totalSize")
+	assert.NoError(t, tree.Generate(testLeafHashes(3), 4))
+	assert.EqualError(t, tree.Generate(testLeafHashes(3), 4), "SMT tree already filled")
+	_, err = tree.GetMerkleProof(4)
+	assert.EqualError(t, err, "node index is too big for node count")
+}
+
+func TestKaryTree(t *testing.T) {
+	for n := 1; n <= 70; n++ {
+		leaves := testLeafHashes(n)
+		binaryTree := newUnbalancedTree(sha256.New(), HashTwoValues, false)
+		assert.NoError(t, binaryTree.Generate(leaves, 0))
+		tree := newKaryTree(2, sha256.New())
+		assert.NoError(t, tree.Generate(leaves, 0))
+		assert.Equal(t, binaryTree.RootHash(), tree.RootHash(), "%d leaves", n)
+
+		for _, arity := range []int{3, 4, 5} {
+			tree := newKaryTree(arity, sha256.New())
+			assert.NoError(t, tree.Generate(leaves, 0))
+			for i := 0; i < n; i++ {
+				steps, err := tree.proofSteps(uint64(i))
+				assert.NoError(t, err)
+				valid, err := ValidateKaryProofHashes(leaves[i], steps, tree.RootHash(), sha256.New())
+				assert.NoError(t, err, "leaf %d of %d with arity %d", i, n, arity)
+				assert.True(t, valid)
+			}
+			_, err := tree.proofSteps(uint64(n))
+			assert.EqualError(t, err, "node index is too big for node count")
+		}
+	}
+
+	// a full tree of 16 leaves with arity 4 has two levels above the leaves
+	leaves := testLeafHashes(16)
+	tree := newKaryTree(4, sha256.New())
+	assert.NoError(t, tree.Generate(leaves, 0))
+	steps, err := tree.proofSteps(6)
+	assert.NoError(t, err)
+	assert.Len(t, steps, 2)
+	assert.Equal(t, append(append([]byte{}, leaves[4]...), leaves[5]...), steps[0].Left)
+	assert.Equal(t, leaves[7], steps[0].Right)
+	assert.Len(t, steps[1].Left, 32)
+	assert.Len(t, steps[1].Right, 2*32)
+
+	tree = newKaryTree(4, sha256.New())
+	assert.Nil(t, tree.RootHash())
+	_, err = tree.proofSteps(0)
+	assert.EqualError(t, err, "Tree is empty")
+	assert.EqualError(t, tree.Generate(nil, 0), "Empty tree")
+}
@@ -5,13 +5,16 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 
 	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // Property uniquely identifies a LeafNode
@@ -76,6 +79,29 @@ func (n Property) CompactName() (pn []byte) {
 	return append(pn, n.Compact...)
 }
 
+// FieldNums returns the compact name of a property as a list of numbers, one per property in the path that has a
+// compact name. Map keys are decoded as big endian numbers, so keys longer than 8 bytes are not supported.
+func (n Property) FieldNums() (*proofspb.FieldNums, error) {
+	var components []uint64
+	if n.Parent != nil {
+		parent, err := n.Parent.FieldNums()
+		if err != nil {
+			return nil, err
+		}
+		components = parent.Components
+	}
+	if len(n.Compact) == 0 {
+		return &proofspb.FieldNums{Components: components}, nil
+	}
+	if len(n.Compact) > 8 {
+		return nil, errors.Errorf("compact name %x of %s does not fit into a uint64", n.Compact, n.ReadableName())
+	}
+
+	padded := make([]byte, 8)
+	copy(padded[8-len(n.Compact):], n.Compact)
+	return &proofspb.FieldNums{Components: append(components, binary.BigEndian.Uint64(padded))}, nil
+}
+
 // FieldProp returns a child Property representing a field of a struct
 func (n Property) FieldProp(name string, num FieldNum) (field Property) {
 	return Property{
@@ -131,6 +157,13 @@ func (n Property) LengthProp(readablePropertyLengthSuffix string) Property {
 	}
 }
 
+// WithNameFormat returns a copy of the property that derives its readable name from its parent with the given format.
+// The format gets the readable name of the parent and the text of the property, see SubFieldFormat and ElemFormat.
+func (n Property) WithNameFormat(format string) Property {
+	n.NameFormat = format
+	return n
+}
+
 // ExtractFieldTags takes the protobuf tag string of a struct field and returns the field name and number
 func ExtractFieldTags(protobufTag string) (string, FieldNum, error) {
 	var err error
@@ -199,6 +232,170 @@ func AsBytes(propName proofspb.PropertyName) []byte {
 	return nil
 }
 
+// ProofToBinaryPath returns the field number path of the property of a proof for a document of msgType, the type of a
+// generated message struct or a pointer to it. The path is the one returned by Property.FieldNums, i.e. the numbers of
+// the fields followed by the indexes of repeated field elements. Length leaves, using the
+// DefaultReadablePropertyLengthSuffix, have the path of their field. Map fields are not supported.
+func ProofToBinaryPath(msgType reflect.Type, p *proofspb.Proof) ([]uint64, error) {
+	md, err := messageDescriptorOf(msgType)
+	if err != nil {
+		return nil, err
+	}
+
+	switch pn := p.Property.(type) {
+	case *proofspb.Proof_ReadableName:
+		return readableToBinaryPath(md, pn.ReadableName)
+	case *proofspb.Proof_CompactName:
+		return compactToBinaryPath(md, pn.CompactName)
+	}
+	return nil, errors.New("proof has no property name")
+}
+
+// BinaryPathToReadableName is the inverse of ProofToBinaryPath and returns the readable property name of a field number
+// path for a document of msgType. Paths ending with a repeated field name the field, not its length leaf.
+func BinaryPathToReadableName(msgType reflect.Type, path []uint64) (string, error) {
+	prop, err := binaryPathToProperty(msgType, path)
+	if err != nil {
+		return "", err
+	}
+	return prop.ReadableName(), nil
+}
+
+// binaryPathToProperty returns the property of a field number path for a document of msgType
+func binaryPathToProperty(msgType reflect.Type, path []uint64) (Property, error) {
+	md, err := messageDescriptorOf(msgType)
+	if err != nil {
+		return Empty, err
+	}
+	if len(path) == 0 {
+		return Empty, errors.New("empty binary path")
+	}
+
+	prop := Empty
+	for i := 0; i < len(path); i++ {
+		if md == nil {
+			return Empty, errors.Errorf("%s has no fields", prop.ReadableName())
+		}
+		fd := md.Fields().ByNumber(protoreflect.FieldNumber(path[i]))
+		if fd == nil || path[i] > math.MaxInt32 {
+			return Empty, errors.Errorf("no field %d in %s", path[i], md.FullName())
+		}
+		if fd.IsMap() {
+			return Empty, errors.Errorf("map field %s is not supported", fd.Name())
+		}
+		prop = prop.FieldProp(string(fd.Name()), FieldNum(fd.Number()))
+		md = fd.Message()
+		if fd.IsList() && i+1 < len(path) {
+			i++
+			prop = prop.SliceElemProp(FieldNumForSliceLength(path[i]))
+		} else if fd.IsList() {
+			md = nil
+		}
+	}
+	return prop, nil
+}
+
+// messageDescriptorOf returns the descriptor of a generated message type
+func messageDescriptorOf(msgType reflect.Type) (protoreflect.MessageDescriptor, error) {
+	if msgType.Kind() != reflect.Ptr {
+		msgType = reflect.PtrTo(msgType)
+	}
+	message, ok := reflect.New(msgType.Elem()).Interface().(proto.Message)
+	if !ok {
+		return nil, errors.Errorf("%s is not a protobuf message", msgType.Elem())
+	}
+	return proto.MessageReflect(message).Descriptor(), nil
+}
+
+// readableToBinaryPath resolves a readable name like `valueC[1].valueA` field by field
+func readableToBinaryPath(md protoreflect.MessageDescriptor, name string) ([]uint64, error) {
+	var path []uint64
+	var fd protoreflect.FieldDescriptor
+	rest := name
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if fd == nil || !fd.IsList() || end < 0 {
+				return nil, errors.Errorf("unexpected element in %s", name)
+			}
+			index, err := strconv.ParseUint(rest[1:end], 10, 64)
+			if err != nil {
+				return nil, errors.Errorf("invalid index in %s: %s", name, err)
+			}
+			path = append(path, index)
+			md, rest = fd.Message(), rest[end+1:]
+			fd = nil
+			if rest == "" {
+				return path, nil
+			}
+			if !strings.HasPrefix(rest, ".") {
+				return nil, errors.Errorf("unexpected %s in %s", rest, name)
+			}
+			rest = rest[1:]
+		case fd != nil:
+			if !strings.HasPrefix(rest, ".") {
+				return nil, errors.Errorf("unexpected %s in %s", rest, name)
+			}
+			if fd.IsList() && rest[1:] == DefaultReadablePropertyLengthSuffix {
+				return path, nil
+			}
+			if fd.IsList() {
+				return nil, errors.Errorf("missing index of %s in %s", fd.Name(), name)
+			}
+			md, rest = fd.Message(), rest[1:]
+		}
+
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+		if md == nil {
+			return nil, errors.Errorf("no field %s in %s", rest[:end], name)
+		}
+		fd = md.Fields().ByName(protoreflect.Name(rest[:end]))
+		if fd == nil {
+			return nil, errors.Errorf("no field %s in %s", rest[:end], md.FullName())
+		}
+		if fd.IsMap() {
+			return nil, errors.Errorf("map field %s is not supported", fd.Name())
+		}
+		path = append(path, uint64(fd.Number()))
+		rest = rest[end:]
+	}
+	return path, nil
+}
+
+// compactToBinaryPath splits a compact name into the 4 byte field numbers and 8 byte indexes of repeated fields
+func compactToBinaryPath(md protoreflect.MessageDescriptor, compact []byte) ([]uint64, error) {
+	var path []uint64
+	for len(compact) > 0 {
+		if md == nil || len(compact) < 4 {
+			return nil, errors.Errorf("malformed compact name, %x left", compact)
+		}
+		num := binary.BigEndian.Uint32(compact)
+		fd := md.Fields().ByNumber(protoreflect.FieldNumber(num))
+		if fd == nil {
+			return nil, errors.Errorf("no field %d in %s", num, md.FullName())
+		}
+		if fd.IsMap() {
+			return nil, errors.Errorf("map field %s is not supported", fd.Name())
+		}
+		path = append(path, uint64(num))
+		md, compact = fd.Message(), compact[4:]
+		if fd.IsList() && len(compact) > 0 {
+			if len(compact) < 8 {
+				return nil, errors.Errorf("malformed compact name, %x left", compact)
+			}
+			path = append(path, binary.BigEndian.Uint64(compact))
+			compact = compact[8:]
+		} else if fd.IsList() {
+			md = nil
+		}
+	}
+	return path, nil
+}
+
 func padTo(bs []byte, totalLength uint64) ([]byte, error) {
 	if uint64(len(bs)) > totalLength {
 		return nil, fmt.Errorf("given []byte longer than %d", totalLength)
@@ -211,6 +408,8 @@ func padTo(bs []byte, totalLength uint64) ([]byte, error) {
 func keyNames(key interface{}, keyLength uint64) (string, []byte, error) {
 	// special compound cases
 	switch k := key.(type) {
+	case []interface{}:
+		return compositeKeyNames(k)
 	case []byte:
 		readableKey := "0x" + hex.EncodeToString(k)
 		compactKeyBytes, err := padTo(k, keyLength)
@@ -284,3 +483,24 @@ func keyNames(key interface{}, keyLength uint64) (string, []byte, error) {
 
 	return "", nil, fmt.Errorf("unsupported key type: %T", key)
 }
+
+// compositeKeyNames returns the names of a key combining several fields, see the mapping_key option. The readable name
+// joins the readable names of the parts with commas, commas within the parts are escaped. The compact name joins the
+// compact names of the parts, each prefixed by its length as a single byte. The parts aren't padded.
+func compositeKeyNames(parts []interface{}) (string, []byte, error) {
+	readableParts := make([]string, len(parts))
+	var compactKey []byte
+	for i, part := range parts {
+		readable, compact, err := keyNames(part, fetchLengthFromInterface(reflect.ValueOf(part)))
+		if err != nil {
+			return "", nil, err
+		}
+		if len(compact) > math.MaxUint8 {
+			return "", nil, fmt.Errorf("composite key part %s is longer than %d bytes", readable, math.MaxUint8)
+		}
+		readableParts[i] = strings.ReplaceAll(readable, ",", `\,`)
+		compactKey = append(compactKey, byte(len(compact)))
+		compactKey = append(compactKey, compact...)
+	}
+	return strings.Join(readableParts, ","), compactKey, nil
+}
@@ -2,8 +2,11 @@ package proofs
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/centrifuge/precise-proofs/examples/documents"
+	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,6 +45,18 @@ func TestPropertyName_NoParent(t *testing.T) {
 	assert.Equal(t, "5", sliceElemProp.ReadableName())
 	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 5}, sliceElemProp.CompactName())
 
+	// indexes above the range of a 32 bit int keep all their bits
+	largeIndex := uint64(1)<<31 + 7
+	sliceElemProp = Empty.FieldProp("field", 43).SliceElemProp(FieldNumForSliceLength(largeIndex))
+	assert.Equal(t, "field[2147483655]", sliceElemProp.ReadableName())
+	assert.Equal(t, []byte{0, 0, 0, 43, 0, 0, 0, 0, 0x80, 0, 0, 7}, sliceElemProp.CompactName())
+	fieldNums, err := sliceElemProp.FieldNums()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{43, largeIndex}, fieldNums.Components)
+	sliceElemProp = Empty.SliceElemProp(FieldNumForSliceLength(uint64(1) << 40))
+	assert.Equal(t, "1099511627776", sliceElemProp.ReadableName())
+	assert.Equal(t, []byte{0, 0, 1, 0, 0, 0, 0, 0}, sliceElemProp.CompactName())
+
 	mapElemProp, err := Empty.MapElemProp(fmt.Errorf("not a valid key type"), 32)
 	assert.Error(t, err)
 
@@ -195,4 +210,112 @@ func TestKeyNames(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "4", s)
 	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 4}, bs)
+
+	// composite keys
+	s, bs, err = keyNames([]interface{}{"a,b", int32(4), [2]byte{0x2f, 0xa2}}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, `a\,b,4,0x2fa2`, s)
+	assert.Equal(t, []byte{3, 'a', ',', 'b', 4, 0, 0, 0, 4, 2, 0x2f, 0xa2}, bs)
+
+	_, _, err = keyNames([]interface{}{"a", 4.2}, 0)
+	assert.EqualError(t, err, "unsupported key type: float64")
+}
+
+func TestProperty_FieldNums(t *testing.T) {
+	prop := Empty.FieldProp("valueD", 4).FieldProp("valueA", 1).FieldProp("valueA", 1)
+	fieldNums, err := prop.FieldNums()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{4, 1, 1}, fieldNums.Components)
+
+	elemProp := NewProperty("doc", 42).FieldProp("valueC", 3).SliceElemProp(7).FieldProp("valueA", 1)
+	fieldNums, err = elemProp.FieldNums()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{42, 3, 7, 1}, fieldNums.Components)
+
+	// length properties share the compact name of their field
+	fieldNums, err = Empty.FieldProp("valueC", 3).LengthProp(DefaultReadablePropertyLengthSuffix).FieldNums()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{3}, fieldNums.Components)
+
+	mapProp, err := Empty.FieldProp("value", 1).MapElemProp(int32(-1), 0)
+	assert.NoError(t, err)
+	fieldNums, err = mapProp.FieldNums()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 0xffffffff}, fieldNums.Components)
+
+	mapProp, err = Empty.FieldProp("value", 1).MapElemProp("key", 32)
+	assert.NoError(t, err)
+	_, err = mapProp.FieldNums()
+	assert.EqualError(t, err, "compact name 00000000000000000000000000000000000000000000000000000000006b6579 of value[key] does not fit into a uint64")
+}
+
+func TestProofToBinaryPath(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleDocument{ValueA: "foo", ValueNotHashed: make([]byte, 32)}))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("valueA")
+	assert.NoError(t, err)
+
+	exampleType := reflect.TypeOf(documentspb.ExampleDocument{})
+	path, err := ProofToBinaryPath(exampleType, &proof)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1}, path)
+	name, err := BinaryPathToReadableName(reflect.TypeOf(&documentspb.ExampleDocument{}), path)
+	assert.NoError(t, err)
+	assert.Equal(t, "valueA", name)
+
+	// nested and repeated fields with readable and compact names
+	nestedType := reflect.TypeOf(documentspb.NestedRepeatedDocument{})
+	for _, compact := range []bool{false, true} {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, CompactProperties: compact})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+		assert.NoError(t, doctree.Generate())
+		for _, leaf := range doctree.GetLeaves() {
+			proof, err := doctree.CreateProof(leaf.Property.ReadableName())
+			assert.NoError(t, err)
+			path, err := ProofToBinaryPath(nestedType, &proof)
+			assert.NoError(t, err)
+			fieldNums, err := leaf.Property.FieldNums()
+			assert.NoError(t, err)
+			assert.Equal(t, fieldNums.Components, path)
+
+			name, err := BinaryPathToReadableName(nestedType, path)
+			assert.NoError(t, err)
+			if doctree.isLengthProp(leaf.Property) {
+				assert.Equal(t, leaf.Property.Parent.ReadableName(), name)
+			} else {
+				assert.Equal(t, leaf.Property.ReadableName(), name)
+			}
+		}
+	}
+
+	_, err = ProofToBinaryPath(nestedType, &proofspb.Proof{Property: ReadableName("valueC.valueA")})
+	assert.EqualError(t, err, "missing index of valueC in valueC.valueA")
+	_, err = ProofToBinaryPath(nestedType, &proofspb.Proof{Property: ReadableName("valueX")})
+	assert.EqualError(t, err, "no field valueX in documents.NestedRepeatedDocument")
+	_, err = ProofToBinaryPath(reflect.TypeOf(0), &proofspb.Proof{Property: ReadableName("valueA")})
+	assert.EqualError(t, err, "int is not a protobuf message")
+	_, err = BinaryPathToReadableName(nestedType, []uint64{9})
+	assert.EqualError(t, err, "no field 9 in documents.NestedRepeatedDocument")
+}
+
+func TestProperty_WithNameFormat(t *testing.T) {
+	parent := Empty.FieldProp("parent", 1)
+	prop := parent.FieldProp("child", 2).WithNameFormat("%s/%s")
+	assert.Equal(t, "parent/child", prop.ReadableName())
+	assert.Equal(t, []byte{0, 0, 0, 1, 0, 0, 0, 2}, prop.CompactName())
+	assert.Equal(t, "parent.child", parent.FieldProp("child", 2).ReadableName())
+	assert.Equal(t, "parent/child/grandchild", prop.FieldProp("grandchild", 3).WithNameFormat("%s/%s").ReadableName())
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeaf(LeafNode{Property: prop, Value: []byte("foo"), Salt: make([]byte, 32)}))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("parent/child")
+	assert.NoError(t, err)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
 }
@@ -17,7 +17,8 @@ Available Protobuf Options
 Fields can be excluded from the flattener by setting the custom protobuf option
 `proofs.exclude_from_tree` found in `proofs/proto/proof.proto`.
 
-Fields can be treated as raw (already hashed values) by setting the option `proofs.hashed_field`.
+Fields can be treated as raw (already hashed values) by setting the option `proofs.hashed_field`. The option is
+supported on `bytes` and `repeated bytes` fields, in the latter case every element becomes a hashed leaf.
 
 Field salts are optional. This will make the proofs simpler to validate. Only recommended on fields that have higher variadic nature, like hashes.
 
@@ -158,7 +159,8 @@ Field for slice/map length
 
 We encode the length of a slice or map field in the tree as an additional leaf so a proof can
 be created about the size of a field. Default is "_length". The new added length field can be customized
-with the ReadablePropertyLengthSuffix option.
+with the ReadablePropertyLengthSuffix option. The length is encoded as an 8 byte big endian integer unless a
+LengthEncoder is set.
 
 	message Document {
 	  repeated string fieldA = 1;
@@ -176,11 +178,38 @@ Library supports padding bytes and string field, one usage of `proto.field_lengt
 Fixed Length Tree
 
 `TreeOption.TreeDepth` is used to define an optional fixed length tree. If this option is provided, the tree will be extended to have the depth specified in the option, so a fixed number of `(2**TreeDepth)` leaves. Empty leaves with hash `hash([]byte{})` will be added to the tree if client does not provide enough leaf nodes.  If the provided leaf nodes surpass `(2**TreeDepth)`, an error will be returned. Fixed length tree does not support sorting by hash option.
+As all proofs of a fixed length tree have the same number of hashes, `DocumentTree.CreateUniformProof` can be used to
+create proofs that don't reveal the number of fields of a document. `DocumentTree.CreateEmptyLeafProof` proves that
+a padding slot of the tree is empty.
 
 Use Customized Leaf Hash Function
 
 `TreeOption.LeafHash` is used to define hash funtion used by leaf node, when do hashing on leaf node of document tree this hash funtion will be used instead of `TreeOption.Hash`. If this option is not provided, then `TreeOption.Hash` will be used when do leaf node hashing operation.
 
+Custom Node Hashing
+
+Inner nodes of the tree are calculated by hashing the concatenation of their two children (see `HashTwoValues`).
+`TreeOption.NodeHasher` allows replacing this, e.g. to prefix the children with their length or a domain separator.
+The same function is used when validating proofs with `DocumentTree.ValidateProof`.
+
+Versioned Hashing
+
+`TreeOption.SchemeVersion` prepends a version byte to the preimage of every leaf and to the inputs of every inner node,
+so roots of different versions of a hashing scheme never collide and proofs of one version fail to validate against
+trees of another. Trees validating proofs have to be created with the same version.
+
+Standalone Verification
+
+The `verify` subpackage validates proofs with the default leaf and node hashing without importing the merkle tree
+implementation, e.g. for verifiers compiled to WebAssembly. `verify.VerifyStandalone` validates a complete proof.
+`ValidateProofHashes` and `ValidateProofSortedHashes` are implemented by it.
+
+Keccak256
+
+Ethereum uses keccak256 instead of the standardized SHA3-256. `NewKeccakHasher` returns a hash that can be plugged
+into `TreeOption.Hash` (ideally together with `TreeOption.EnableHashSorting`) to create proofs that can be verified
+by smart contracts. `TreeOption.EVMEncoding` encodes integers as 32 byte words, matching the EVM's int256/uint256.
+
 Append Fields
 
 Simple Structure:
@@ -275,6 +304,28 @@ Example:
 Result:
 	- document.phone_numbers["home"] = "+1123 4567 89"
 
+Composite Mapping Keys:
+A `mapping_key` can combine several fields separated by commas, e.g. `(proofs.mapping_key) = "country,year"`. The
+readable key joins the keys of the fields with commas, e.g. `entries[DE,2020]`, and the compact key joins their compact
+encodings, each prefixed by its length as a single byte. The `field_length` option doesn't apply to composite keys.
+
+
+Nested Append Fields:
+A message field inside an appended message is only supported if it has the append_fields option as well. Its fields
+are merged in the order of their field numbers and the result is appended like a scalar field.
+
+	message Person {
+		string first = 1;
+		Name name = 2 [ (proofs.append_fields) = true; ];
+	}
+
+	message Document {
+		Person person = 1 [ (proofs.append_fields) = true; ];
+	}
+
+Result:
+	- document.person = person.first + person.name.first + person.name.last
+
 
 */
 package proofs
@@ -282,16 +333,30 @@ package proofs
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"hash"
+	"io"
+	"math/bits"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/centrifuge/precise-proofs/proofs/proto"
+	"github.com/centrifuge/precise-proofs/proofs/verify"
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/xsleonard/go-merkle"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // DefaultReadablePropertyLengthSuffix is the suffix used to store the length of slices (repeated) fields in the tree. It can be
@@ -299,6 +364,10 @@ import (
 const DefaultReadablePropertyLengthSuffix = "length"
 const SaltsFieldName = "Salts"
 
+// UnknownFieldsName is the readable name of the leaf holding the unknown fields of a message, see
+// TreeOptions.IncludeUnknownFields. Its compact name uses the reserved field number 0.
+const UnknownFieldsName = "XXX_unrecognized"
+
 // TreeOptions allows customizing the generation of the tree
 type TreeOptions struct {
 	//	EnableHashSorting: Implement a merkle tree with sorted hashes
@@ -308,47 +377,367 @@ type TreeOptions struct {
 	// leaf that represents the length of the slice. The default suffix is `_length`, although it is customizable so it
 	// does not collide with potential field names of your own proto structs.
 	ReadablePropertyLengthSuffix string
-	Hash                         hash.Hash
-	LeafHash                     hash.Hash
+	// Hash is used for the internal nodes of the tree, LeafHash for the leaves and defaults to Hash. Both may have
+	// different digest sizes, but EVMEncoding requires 32 byte hashes as on chain verifiers work with bytes32 words.
+	Hash     hash.Hash
+	LeafHash hash.Hash
 	// ParentPrefix defines an arbitrary prefix to prepend to the parent, so all fields are prepended with it
 	ParentPrefix                Property
 	CompactProperties           bool
 	FixedLengthFieldLeftPadding bool
 	TreeDepth                   uint
+	// PadToPowerOfTwo pads the leaves with empty leaves to the next power of two when the tree is generated, so the
+	// tree is a perfect binary tree and all proofs have the same length. Unlike TreeDepth the size follows the number
+	// of leaves, which proofs then reveal approximately. It can't be combined with TreeDepth or EnableHashSorting.
+	PadToPowerOfTwo bool
+	// Arity is the maximal number of children of the inner nodes of the tree, 2 if not set. The parent of a group of
+	// nodes is the hash of their concatenation, so proofs get shorter with a higher arity but carry more siblings per
+	// level: each step of a proof has the concatenated siblings left of the node in Left and the ones right of it in
+	// Right, see ValidateKaryProofHashes. An arity above 2 can't be combined with TreeDepth, PadToPowerOfTwo,
+	// EnableHashSorting, a NodeHasher or a SchemeVersion.
+	Arity int
+	// SingleLeafRootMode defines the root of a tree with exactly one leaf, see SingleLeafRootMode. Defaults to the
+	// leaf hash.
+	SingleLeafRootMode SingleLeafRootMode
+	// CommitLeafCount appends the reserved LeafCountProp leaf when the tree is generated, which holds the total number
+	// of leaves including itself as a big endian uint64. CreateLeafCountProof proves it, so verifiers can confirm how
+	// many leaves the tree has. Trees committing their leaf count can't be extended.
+	CommitLeafCount bool
+	// SortByCompact orders the leaves by their compact names even if proofs use readable names. It has no effect if
+	// CompactProperties is set, as the leaves are then always ordered by compact names. Field numbers and the indexes of
+	// repeated fields have a fixed width in compact names, so unless the document has map fields with keys of varying
+	// length, e.g. unpadded string keys, this is the order of the field number paths returned by Property.FieldNums,
+	// as used by systems that key leaves on those paths.
+	SortByCompact bool
+	// StripPrefixInProof removes the ParentPrefix from the property names in proofs, so they can be verified against
+	// the un-prefixed schema. The prefix is still part of the leaf hashes and is re-added when validating proofs.
+	StripPrefixInProof bool
+	// LeafTransform is applied to the values of all leaves created from a document before they are hashed, see
+	// LeafTransform.
+	LeafTransform LeafTransform
+	// StrictEnums rejects enum values that are not defined in the enum's descriptor instead of adding their numeric
+	// value to the tree.
+	StrictEnums bool
+	// VerifyCachedLeafHashes recomputes the hashes of leaves that are added with a Hash but without Hashed, e.g. leaves
+	// restored from a cache holding both the value and its hash, when the tree is generated. Generate fails if a cached
+	// hash doesn't match the value, instead of using the cached hash.
+	VerifyCachedLeafHashes bool
+	// IncludeUnknownFields adds a leaf for the unknown fields of every message that has any, so data added by newer
+	// versions of a schema is committed to as well. The leaf is named UnknownFieldsName.
+	IncludeUnknownFields bool
+	// NodeHasher calculates the inner nodes of the tree from their children, both when generating the tree and when
+	// validating proofs. Defaults to HashTwoValues.
+	NodeHasher NodeHasher
+	// EVMEncoding encodes integer and enum values as 32 byte words instead of their fixed size big endian encoding.
+	// Negative values use two's complement, so they can be compared as int256 on chain.
+	EVMEncoding bool
+	// LengthEncoder encodes the value of the length leaves of repeated and map fields. Defaults to an 8 byte big endian
+	// integer.
+	LengthEncoder LengthEncoder
+	// OmitZeroLengthLeaves skips the length leaf of empty repeated and map fields, so the root of a document with an
+	// empty field equals the root of the same document without that field in its schema. The absence of the length
+	// leaf can't be proven, so proofs about an empty field are not possible with this option.
+	OmitZeroLengthLeaves bool
+	// DeriveLengthSalts salts the length leaf of a repeated or map field with a salt derived from the salt of the field,
+	// see DeriveLengthSalt, instead of using the salt of the field directly. The salt of the length leaf can then be
+	// reproduced from the field salt without being persisted separately.
+	DeriveLengthSalts bool
+	// TypeTagValues prefixes the value of every field with a one byte type tag (see TypeTagBytes and the following
+	// constants), so values of different types with the same encoding, e.g. a string and bytes, result in different
+	// leaves. The tag is part of the leaf value and therefore of the proofs, so validation includes it as well. Values
+	// of appended fields are tagged individually, length leaves are not tagged.
+	TypeTagValues bool
+	// EnumAsString uses the names of enum values as the readable names of map elements keyed by an enum, which
+	// happens for repeated fields with a mapping_key of an enum type. The compact names still use the numbers.
+	EnumAsString bool
+	// ExcludeFields lists the readable names of fields that are not added to the tree, e.g. `valueD.valueA` to only
+	// exclude the field valueA of the message in valueD. Names include the ParentPrefix and elements of repeated
+	// fields have to be listed individually, e.g. `valueC[0].valueA`. Unlike the exclude_from_tree option, this
+	// applies to single paths instead of every occurrence of a field.
+	ExcludeFields []string
+	// HashValuesOver replaces every value longer than the given number of bytes by its hash using LeafHash, e.g. for
+	// embedded files. The leaf is added as an already hashed leaf like a field with the hashed_field option, so its
+	// property name and salt are not part of the leaf hash and proofs carry the hash instead of the value. 0 disables
+	// hashing of values.
+	HashValuesOver int
+	// UseJSONNames uses the JSON names of fields, e.g. `valueBytes1` instead of `value_bytes1`, in the readable
+	// names of the properties. This applies to ExcludeFields as well. Compact names are not affected.
+	UseJSONNames bool
+	// NormalizeNameCase is applied to the names of fields in the readable names of the properties, e.g.
+	// strings.ToLower, so mixed-case names don't sort apart from lowercase ones. This changes the order of the leaves
+	// and therefore the root. Compact names and the keys of map fields are not affected, and ExcludeFields has to list
+	// the normalized names. Fields whose names only differ in case collide and fail to flatten.
+	NormalizeNameCase func(name string) string
+	// SaltLength zero-extends salts shorter than SaltLength bytes with trailing zeros before they are hashed, for
+	// legacy documents with e.g. 16 byte salts. Longer salts are rejected and generated salts have SaltLength bytes.
+	// The proofs contain the salts as provided, and the tree validates them with the same extension. If not set,
+	// salts have to be exactly 32 bytes.
+	SaltLength int
+	// HashSalt concatenates the hash of the salt, calculated with LeafHash, instead of the salt itself to the property
+	// name and value of a leaf, for schemes that commit to salts by their hash. Salts can have any length then. Leaves
+	// without a salt are not affected.
+	HashSalt bool
+	// AnyResolver resolves the type URL of google.protobuf.Any fields, which are then flattened as their concrete
+	// message under the property of the field. Any fields are flattened as a message with type_url and value if not set.
+	AnyResolver jsonpb.AnyResolver
+	// SchemeVersion is prepended to the preimage of every leaf hash and to the inputs of every inner node, so trees of
+	// different versions of a hashing scheme have distinct roots and proofs of one version don't validate against
+	// roots of another. 0, the default, hashes without a version byte. The version byte is prepended to the left child
+	// passed to the NodeHasher. Validation has to use the same version. It can't be combined with an Arity above 2.
+	SchemeVersion byte
+}
+
+// rootAffectingOptions lists the TreeOptions fields that change the leaves or the way they are hashed, and therefore
+// the root of a tree.
+var rootAffectingOptions = []string{
+	"EnableHashSorting",
+	"Salts",
+	"ReadablePropertyLengthSuffix",
+	"Hash",
+	"LeafHash",
+	"ParentPrefix",
+	"CompactProperties",
+	"FixedLengthFieldLeftPadding",
+	"TreeDepth",
+	"PadToPowerOfTwo",
+	"Arity",
+	"SingleLeafRootMode",
+	"CommitLeafCount",
+	"SortByCompact",
+	"LeafTransform",
+	"IncludeUnknownFields",
+	"NodeHasher",
+	"EVMEncoding",
+	"LengthEncoder",
+	"OmitZeroLengthLeaves",
+	"DeriveLengthSalts",
+	"TypeTagValues",
+	"EnumAsString",
+	"ExcludeFields",
+	"HashValuesOver",
+	"UseJSONNames",
+	"NormalizeNameCase",
+	"SaltLength",
+	"HashSalt",
+	"AnyResolver",
+	"SchemeVersion",
+}
+
+// cosmeticOptions lists the TreeOptions fields that only affect the format of proofs or the validation of documents
+// but never the root of a tree.
+var cosmeticOptions = []string{
+	"StripPrefixInProof",
+	"StrictEnums",
+	"VerifyCachedLeafHashes",
+}
+
+// AffectsRoot returns the names of the TreeOptions fields that change the computed root of a tree. Two trees created
+// from the same document are only guaranteed to have the same root if all of these options match.
+func (opts TreeOptions) AffectsRoot() []string {
+	return append([]string(nil), rootAffectingOptions...)
 }
 
 type Salts func(compact []byte) ([]byte, error)
 
-func defaultGetSalt(message proto.Message) (Salts, error) {
+// SaltStore persists the salts of a document outside of the document itself, so a tree can be rebuilt later with the
+// same salts. The Salts returned by Load have to return a nil salt for unknown compact names, Load may return nil
+// Salts if nothing was stored for the document yet. Save receives all salts used by the document, including the
+// loaded ones.
+type SaltStore interface {
+	Load(docID string) (Salts, error)
+	Save(docID string, salts []*proofspb.Salt) error
+}
+
+// NewSpecSalts returns a Salts implementation deriving the salt of every leaf from a secret seed, so other
+// implementations can regenerate the salts from the seed instead of receiving them. The salt of a leaf is defined as
+//
+//	SHA256(seed || compactName)
+//
+// where || is byte concatenation and compactName is the compact name of the property of the leaf, including the
+// ParentPrefix, exactly as passed to Salts. The seed has to be kept secret, as anyone knowing it can brute force the
+// values of the leaves.
+func NewSpecSalts(seed []byte) Salts {
+	seed = append([]byte(nil), seed...)
+	return func(compact []byte) ([]byte, error) {
+		salt := sha256.Sum256(append(append([]byte(nil), seed...), compact...))
+		return salt[:], nil
+	}
+}
+
+// SingleLeafRootMode defines how the root of a tree with a single leaf is calculated, as verifiers differ in what they
+// expect. The modes other than SingleLeafRootLeafHash hash the leaf with a sibling using the NodeHasher, and proofs of
+// the leaf contain that sibling, so they validate like any other proof.
+type SingleLeafRootMode int
+
+const (
+	// SingleLeafRootLeafHash uses the leaf hash as root
+	SingleLeafRootLeafHash SingleLeafRootMode = iota
+	// SingleLeafRootHashOfLeaf hashes the leaf with an empty sibling, which results in hash(leaf) for the default
+	// NodeHasher
+	SingleLeafRootHashOfLeaf
+	// SingleLeafRootDuplicateAndHash hashes the leaf with itself, which results in hash(leaf || leaf) for the default
+	// NodeHasher
+	SingleLeafRootDuplicateAndHash
+)
+
+// LeafCountProp is the property of the leaf committing to the number of leaves of trees with CommitLeafCount. Its field
+// number 0 is never used by protobuf fields, so its compact name can't collide with the fields of a document.
+var LeafCountProp = Empty.FieldProp("_leafCount", 0)
+
+// LengthEncoder returns the value of the leaf holding the length of a repeated or map field
+type LengthEncoder func(length int) ([]byte, error)
+
+// LeafTransform allows normalizing the value of a leaf (e.g. trimming or lowercasing it) before it is added to the
+// tree. It is applied to the length leaves of repeated and map fields as well, but not to hashed fields. The
+// transformed value is stored in the leaf and therefore part of the proofs, so validation doesn't need to apply it
+// again. The transform has to be deterministic.
+type LeafTransform func(prop Property, value []byte) ([]byte, error)
+
+// saltCollector provides the salts stored in the salts field of a message and generates random salts for the
+// missing ones. Generated salts are collected and written back to the message at once by fillBack.
+type saltCollector struct {
+	salts     []*proofspb.Salt
+	index     map[string][]byte
+	stored    Salts
+	fill      func(salts []*proofspb.Salt) error
+	generated bool
+	// length of generated salts, 32 if not set
+	length int
+}
+
+func newSaltCollector(message proto.Message) (*saltCollector, error) {
 	salts, err := getSaltsFromMessage(message)
 	if err != nil {
 		return nil, err
 	}
-	return func(compact []byte) ([]byte, error) {
-		for _, salt := range salts {
-			if bytes.Compare(salt.GetCompact(), compact) == 0 {
-				return salt.GetValue(), nil
-			}
+	return newSaltCollectorWith(salts, func(salts []*proofspb.Salt) error {
+		return fillBackSalts(message, salts)
+	}), nil
+}
+
+// newSaltCollectorWith returns a saltCollector providing the given salts, fill writes the salts back to the message
+func newSaltCollectorWith(salts []*proofspb.Salt, fill func(salts []*proofspb.Salt) error) *saltCollector {
+	index := make(map[string][]byte, len(salts))
+	for _, salt := range salts {
+		// the first salt for a compact name wins
+		if _, ok := index[string(salt.GetCompact())]; !ok {
+			index[string(salt.GetCompact())] = salt.GetValue()
 		}
-		randbytes := make([]byte, 32)
-		n, err := rand.Read(randbytes)
+	}
+	return &saltCollector{salts: salts, index: index, fill: fill}
+}
+
+// getSalt returns the salt for the given compact name, generating a new one if the message doesn't contain it
+func (c *saltCollector) getSalt(compact []byte) ([]byte, error) {
+	if salt, ok := c.index[string(compact)]; ok {
+		return salt, nil
+	}
+
+	if c.stored != nil {
+		salt, err := c.stored(compact)
 		if err != nil {
 			return nil, err
-		} else if n != 32 {
-			return nil, errors.Wrapf(err, "Only read %d instead of 32 random bytes", n)
 		}
+		if salt != nil {
+			c.salts = append(c.salts, &proofspb.Salt{
+				Compact: compact,
+				Value:   salt,
+			})
+			c.index[string(compact)] = salt
+			return salt, nil
+		}
+	}
+
+	length := c.length
+	if length == 0 {
+		length = 32
+	}
+	randbytes := make([]byte, length)
+	n, err := rand.Read(randbytes)
+	if err != nil {
+		return nil, err
+	} else if n != length {
+		return nil, errors.Wrapf(err, "Only read %d instead of %d random bytes", n, length)
+	}
+
+	c.salts = append(c.salts, &proofspb.Salt{
+		Compact: compact,
+		Value:   randbytes,
+	})
+	c.index[string(compact)] = randbytes
+	c.generated = true
+	return randbytes, nil
+}
+
+// fillBack writes the provided and generated salts back to the message if any salt was generated
+func (c *saltCollector) fillBack() error {
+	if !c.generated {
+		return nil
+	}
+	return c.fill(c.salts)
+}
+
+// saveTo saves the provided and generated salts to the store if any salt was generated
+func (c *saltCollector) saveTo(store SaltStore, docID string) error {
+	if !c.generated {
+		return nil
+	}
+	return store.Save(docID, c.salts)
+}
+
+// ValidateSaltsComplete checks that the salts field of the document contains a salt for every leaf that requires one,
+// e.g. before persisting salts that were generated for an older version of the schema. It returns the hex encoded
+// compact names of the leaves without a salt, assuming the document is used without a ParentPrefix.
+func ValidateSaltsComplete(document proto.Message) ([]string, error) {
+	salts, err := getSaltsFromMessage(document)
+	if err != nil {
+		return nil, err
+	}
+	provided := make(map[string]struct{}, len(salts))
+	for _, salt := range salts {
+		provided[string(salt.GetCompact())] = struct{}{}
+	}
 
-		salt := proofspb.Salt{
-			Compact: compact,
-			Value:   randbytes,
+	var missing []string
+	reported := make(map[string]struct{})
+	collect := func(compact []byte) ([]byte, error) {
+		_, ok := provided[string(compact)]
+		_, seen := reported[string(compact)]
+		if !ok && !seen {
+			missing = append(missing, hex.EncodeToString(compact))
+			reported[string(compact)] = struct{}{}
 		}
-		salts = append(salts, &salt)
-		err = fillBackSalts(message, salts)
-		if err != nil {
-			return nil, err
+		return make([]byte, 32), nil
+	}
+
+	f := messageFlattener{readablePropertyLengthSuffix: DefaultReadablePropertyLengthSuffix, compactProperties: true}
+	err = f.handleValue(Empty, reflect.ValueOf(document), collect, f.readablePropertyLengthSuffix, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	return missing, nil
+}
+
+// ValidateSaltUniqueness checks that no two entries of the salts field of the document share a salt, as reused salts
+// weaken the hiding of the values. It returns the hex encoded compact names of all entries whose salt is used more
+// than once, in the order of the salts field.
+func ValidateSaltUniqueness(document proto.Message) ([]string, error) {
+	salts, err := getSaltsFromMessage(document)
+	if err != nil {
+		return nil, err
+	}
+	uses := make(map[string]int, len(salts))
+	for _, salt := range salts {
+		uses[string(salt.GetValue())]++
+	}
+
+	var reused []string
+	for _, salt := range salts {
+		if len(salt.GetValue()) > 0 && uses[string(salt.GetValue())] > 1 {
+			reused = append(reused, hex.EncodeToString(salt.GetCompact()))
 		}
-		return randbytes, nil
-	}, nil
+	}
+	return reused, nil
 }
 
 // DocumentTree is a helper object to create a merkleTree and proofs for fields in the document
@@ -368,10 +757,38 @@ type DocumentTree struct {
 	parentPrefix                 Property
 	compactProperties            bool
 	fixedLengthFieldLeftPadding  bool
-	nameIndex                    map[string]struct{}
-	propertyIndex                map[string]struct{}
+	nameIndex                    map[string]Property
+	// propertyIndex maps the compact names of the leaves to their readable names
+	propertyIndex                map[string]string
 	fixedNoOfLeafs               uint
 	enableHashSorting            bool
+	sortByCompact                bool
+	stripPrefixInProof           bool
+	leafTransform                LeafTransform
+	strictEnums                  bool
+	verifyCachedLeafHashes       bool
+	includeUnknownFields         bool
+	nodeHasher                   NodeHasher
+	evmEncoding                  bool
+	lengthEncoder                LengthEncoder
+	omitZeroLengthLeaves         bool
+	deriveLengthSalts            bool
+	typeTagValues                bool
+	enumAsString                 bool
+	excludeFields                map[string]struct{}
+	hashValuesOver               int
+	useJSONNames                 bool
+	normalizeNameCase            func(name string) string
+	saltLength                   int
+	hashSalt                     bool
+	schemeVersion                byte
+	padToPowerOfTwo              bool
+	arity                        int
+	singleLeafRootMode           SingleLeafRootMode
+	commitLeafCount              bool
+	anyResolver                  jsonpb.AnyResolver
+	saltStore                    SaltStore
+	saltStoreDocID               string
 	// 0 means number of leafs is not fixed
 }
 
@@ -384,6 +801,15 @@ func NewDocumentTree(proofOpts TreeOptions) (DocumentTree, error) {
 	if proofOpts.TreeDepth != 0 && proofOpts.EnableHashSorting {
 		return DocumentTree{}, errors.New("Fixed size tree does not support sorting by hash")
 	}
+	if proofOpts.PadToPowerOfTwo && (proofOpts.TreeDepth != 0 || proofOpts.EnableHashSorting) {
+		return DocumentTree{}, errors.New("PadToPowerOfTwo can't be combined with TreeDepth or EnableHashSorting")
+	}
+	if proofOpts.Arity < 0 || proofOpts.Arity == 1 {
+		return DocumentTree{}, fmt.Errorf("Arity has to be at least 2, got %d", proofOpts.Arity)
+	}
+	if proofOpts.Arity > 2 && (proofOpts.TreeDepth != 0 || proofOpts.PadToPowerOfTwo || proofOpts.EnableHashSorting || proofOpts.NodeHasher != nil || proofOpts.SchemeVersion != 0) {
+		return DocumentTree{}, errors.New("Arity above 2 can't be combined with TreeDepth, PadToPowerOfTwo, EnableHashSorting, NodeHasher or SchemeVersion")
+	}
 	var salts Salts
 	if proofOpts.Salts != nil {
 		salts = proofOpts.Salts
@@ -406,20 +832,39 @@ func NewDocumentTree(proofOpts TreeOptions) (DocumentTree, error) {
 		leafHash = proofOpts.LeafHash
 	}
 
+	if proofOpts.SaltLength < 0 {
+		return DocumentTree{}, fmt.Errorf("SaltLength can't be negative, got %d", proofOpts.SaltLength)
+	}
+
+	if proofOpts.EVMEncoding && proofOpts.Hash != nil && (proofOpts.Hash.Size() != 32 || leafHash.Size() != 32) {
+		return DocumentTree{}, fmt.Errorf("EVMEncoding requires 32 byte hashes, got %d byte Hash and %d byte LeafHash", proofOpts.Hash.Size(), leafHash.Size())
+	}
+
+	nodeHasher := NodeHasher(HashTwoValues)
+	if proofOpts.NodeHasher != nil {
+		nodeHasher = proofOpts.NodeHasher
+	}
+	if proofOpts.SchemeVersion != 0 {
+		nodeHasher = versionedNodeHasher(proofOpts.SchemeVersion, nodeHasher)
+	}
+
+	excludeFields := make(map[string]struct{}, len(proofOpts.ExcludeFields))
+	for _, name := range proofOpts.ExcludeFields {
+		excludeFields[name] = struct{}{}
+	}
+
 	var tree merkle.MerkleTree
 	if leavesNo > 0 {
 		emptyHash, err := emptyNodeHash(leafHash)
 		if err != nil {
 			return DocumentTree{}, err
 		}
-		tree = merkle.NewSMT(emptyHash, proofOpts.Hash)
+		tree = newSparseTree(emptyHash, proofOpts.Hash, nodeHasher)
 
+	} else if proofOpts.Arity > 2 {
+		tree = newKaryTree(proofOpts.Arity, proofOpts.Hash)
 	} else {
-		if proofOpts.EnableHashSorting {
-			tree = merkle.NewTreeWithHashSortingEnable(proofOpts.Hash)
-		} else {
-			tree = merkle.NewTree(proofOpts.Hash)
-		}
+		tree = newUnbalancedTree(proofOpts.Hash, nodeHasher, proofOpts.EnableHashSorting)
 	}
 	return DocumentTree{
 		propertyList:                 []Property{},
@@ -432,10 +877,35 @@ func NewDocumentTree(proofOpts TreeOptions) (DocumentTree, error) {
 		parentPrefix:                 proofOpts.ParentPrefix,
 		compactProperties:            proofOpts.CompactProperties,
 		fixedLengthFieldLeftPadding:  proofOpts.FixedLengthFieldLeftPadding,
-		nameIndex:                    make(map[string]struct{}),
-		propertyIndex:                make(map[string]struct{}),
+		nameIndex:                    make(map[string]Property),
+		propertyIndex:                make(map[string]string),
 		fixedNoOfLeafs:               leavesNo,
 		enableHashSorting:            proofOpts.EnableHashSorting,
+		sortByCompact:                proofOpts.SortByCompact,
+		stripPrefixInProof:           proofOpts.StripPrefixInProof,
+		leafTransform:                proofOpts.LeafTransform,
+		strictEnums:                  proofOpts.StrictEnums,
+		verifyCachedLeafHashes:       proofOpts.VerifyCachedLeafHashes,
+		includeUnknownFields:         proofOpts.IncludeUnknownFields,
+		nodeHasher:                   nodeHasher,
+		evmEncoding:                  proofOpts.EVMEncoding,
+		lengthEncoder:                proofOpts.LengthEncoder,
+		omitZeroLengthLeaves:         proofOpts.OmitZeroLengthLeaves,
+		deriveLengthSalts:            proofOpts.DeriveLengthSalts,
+		typeTagValues:                proofOpts.TypeTagValues,
+		enumAsString:                 proofOpts.EnumAsString,
+		excludeFields:                excludeFields,
+		hashValuesOver:               proofOpts.HashValuesOver,
+		useJSONNames:                 proofOpts.UseJSONNames,
+		normalizeNameCase:            proofOpts.NormalizeNameCase,
+		saltLength:                   proofOpts.SaltLength,
+		hashSalt:                     proofOpts.HashSalt,
+		schemeVersion:                proofOpts.SchemeVersion,
+		padToPowerOfTwo:              proofOpts.PadToPowerOfTwo,
+		arity:                        proofOpts.Arity,
+		singleLeafRootMode:           proofOpts.SingleLeafRootMode,
+		commitLeafCount:              proofOpts.CommitLeafCount,
+		anyResolver:                  proofOpts.AnyResolver,
 	}, nil
 }
 
@@ -450,6 +920,114 @@ func NewDocumentTreeWithRootHash(proofOpts TreeOptions, rootHash []byte) (Docume
 	return documentTree, nil
 }
 
+// NewDocumentTreeWithSaltStore returns an empty DocumentTree that takes the salts of the leaves from the store instead
+// of the salts field of the document. AddLeavesFromDocument loads the salts stored for docID and saves them together
+// with the newly generated ones, so later trees built with the same store and docID have the same root.
+func NewDocumentTreeWithSaltStore(proofOpts TreeOptions, store SaltStore, docID string) (DocumentTree, error) {
+	if store == nil {
+		return DocumentTree{}, errors.New("salt store is not set")
+	}
+	if proofOpts.Salts != nil {
+		return DocumentTree{}, errors.New("Salts can't be used together with a salt store")
+	}
+	documentTree, err := NewDocumentTree(proofOpts)
+	if err != nil {
+		return DocumentTree{}, err
+	}
+	documentTree.saltStore = store
+	documentTree.saltStoreDocID = docID
+	return documentTree, nil
+}
+
+// NewDocumentTreeFromHashes returns a generated DocumentTree with a hashed leaf for each property and leaf hash, added
+// in the given order. It reproduces the root of a tree from its PropertyOrder and leaf hashes, e.g. to validate proofs
+// against a tree received from another service. Proofs created by it carry the leaf hash instead of the value.
+func NewDocumentTreeFromHashes(proofOpts TreeOptions, props []Property, hashes [][]byte) (DocumentTree, error) {
+	if len(props) != len(hashes) {
+		return DocumentTree{}, fmt.Errorf("got %d properties but %d hashes", len(props), len(hashes))
+	}
+	documentTree, err := NewDocumentTree(proofOpts)
+	if err != nil {
+		return DocumentTree{}, err
+	}
+	for i, prop := range props {
+		err = documentTree.AddLeaf(LeafNode{Property: prop, Hash: hashes[i], Hashed: true})
+		if err != nil {
+			return DocumentTree{}, err
+		}
+	}
+	err = documentTree.Generate()
+	if err != nil {
+		return DocumentTree{}, err
+	}
+	return documentTree, nil
+}
+
+// RequiredTreeDepth flattens the document with the given options and returns the number of its leaves and the minimal
+// TreeDepth that fits them, which is at least 1 as a TreeDepth of 0 doesn't fix the size. The leaf count includes the
+// LeafCountProp leaf if CommitLeafCount is set. The TreeDepth of the options is ignored. If no Salts are set,
+// placeholder salts are used so the salts of the document are left untouched.
+func RequiredTreeDepth(document proto.Message, opts TreeOptions) (uint, int, error) {
+	opts.TreeDepth = 0
+	if opts.Salts == nil {
+		opts.Salts = func(compact []byte) ([]byte, error) {
+			return make([]byte, 32), nil
+		}
+	}
+	doctree, err := NewDocumentTree(opts)
+	if err != nil {
+		return 0, 0, err
+	}
+	err = doctree.AddLeavesFromDocument(document)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	leafCount := len(doctree.leaves)
+	if opts.CommitLeafCount {
+		leafCount++
+	}
+	if leafCount <= 2 {
+		return 1, leafCount, nil
+	}
+	return uint(bits.Len(uint(leafCount - 1))), leafCount, nil
+}
+
+// FlattenStats flattens the document with the given options without hashing the leaves and returns the number of
+// leaves, the total size of their values and the maximal depth of their properties, e.g. for capacity planning.
+// Values are counted at their full size regardless of HashValuesOver and hashed fields with the size of their hash.
+// If no Salts are set, placeholder salts are used so the salts of the document are left untouched.
+func FlattenStats(document proto.Message, opts TreeOptions) (leafCount int, totalValueBytes int, maxDepth int, err error) {
+	opts.TreeDepth = 0
+	opts.HashValuesOver = 0
+	if opts.Salts == nil {
+		opts.Salts = func(compact []byte) ([]byte, error) {
+			return make([]byte, 32), nil
+		}
+	}
+	doctree, err := NewDocumentTree(opts)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	f := doctree.newFlattener()
+	err = f.handleValue(doctree.parentPrefix, reflect.ValueOf(document), doctree.salts, f.readablePropertyLengthSuffix, nil, false)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	for _, leaf := range f.leaves {
+		if leaf.Hashed {
+			totalValueBytes += len(leaf.Hash)
+		} else {
+			totalValueBytes += len(leaf.Value)
+		}
+		if depth := propertyDepth(leaf.Property); depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+	return len(f.leaves), totalValueBytes, maxDepth, nil
+}
+
 // AddLeaves appends list of leaves to the tree's leaves.
 // This function can be called multiple times and leaves will be added from left to right. Note that the lexicographic
 // sorting doesn't get applied in this method but in the protobuf flattening. The order in which leaves are added in
@@ -482,45 +1060,237 @@ func (doctree *DocumentTree) AddLeaf(leaf LeafNode) error {
 	var pty = leaf.Property
 	var rnStr = pty.ReadableName()
 	var compactStr = fmt.Sprint(pty.CompactName())
-	_, ok := doctree.nameIndex[rnStr]
+	existingProp, ok := doctree.nameIndex[rnStr]
 	if ok {
-		return errors.New("duplicated leaf")
+		if doctree.isLengthProp(pty) || doctree.isLengthProp(existingProp) {
+			return fmt.Errorf("duplicated leaf: readable name %s of a field collides with the length leaf of a repeated or map field, set ReadablePropertyLengthSuffix to a name that no field uses", rnStr)
+		}
+		return fmt.Errorf("duplicated leaf: readable name %s is already used", rnStr)
 	}
-	doctree.nameIndex[rnStr] = struct{}{}
-	_, ok = doctree.propertyIndex[compactStr]
+	existing, ok := doctree.propertyIndex[compactStr]
 	if ok {
-		return errors.New("duplicated leaf")
+		return fmt.Errorf("duplicated leaf: compact name %x of %s is already used by %s", pty.CompactName(), rnStr, existing)
 	}
-	doctree.propertyIndex[compactStr] = struct{}{}
+	doctree.nameIndex[rnStr] = pty
+	doctree.propertyIndex[compactStr] = rnStr
 
 	doctree.leaves = append(doctree.leaves, leaf)
 	return nil
 }
 
+// isLengthProp reports whether the property names the length leaf of a repeated or map field
+func (doctree *DocumentTree) isLengthProp(prop Property) bool {
+	return prop.Parent != nil && len(prop.Compact) == 0 && prop.Text == doctree.readablePropertyLengthSuffix
+}
+
 // AddLeavesFromDocument iterates over a protobuf message, flattens it and adds all leaves to the tree
 func (doctree *DocumentTree) AddLeavesFromDocument(document proto.Message) (err error) {
+	return doctree.addLeavesFrom(func() (*saltCollector, error) {
+		return newSaltCollector(document)
+	}, func(f *messageFlattener, salts Salts) ([]LeafNode, error) {
+		return f.flatten(document, salts, doctree.parentPrefix)
+	})
+}
+
+// AddLeavesFromMessage works like AddLeavesFromDocument for messages of the protoreflect API, which includes messages
+// without generated Go structs, e.g. dynamicpb messages. The message is walked with protoreflect instead of struct
+// reflection and results in the same leaves. Generated salts are written to the salts field of the message.
+func (doctree *DocumentTree) AddLeavesFromMessage(message protoreflect.ProtoMessage) (err error) {
+	m := message.ProtoReflect()
+	return doctree.addLeavesFrom(func() (*saltCollector, error) {
+		return newReflectSaltCollector(m)
+	}, func(f *messageFlattener, salts Salts) ([]LeafNode, error) {
+		return f.flattenReflect(m, salts, doctree.parentPrefix)
+	})
+}
+
+// AddLeavesFromJSON unmarshals the JSON encoded document into document with jsonpb and adds its leaves like
+// AddLeavesFromDocument. Besides the base64 encoding of jsonpb, values of fields with the hashed_field option can be
+// given as 0x prefixed hex strings, which is how hashes are usually passed around. Strings with the 0x prefix are
+// always read as hex.
+func (doctree *DocumentTree) AddLeavesFromJSON(data []byte, document proto.Message) error {
+	err := unmarshalJSONDocument(data, document)
+	if err != nil {
+		return err
+	}
+	return doctree.AddLeavesFromDocument(document)
+}
+
+// unmarshalJSONDocument converts hex encoded values of hashed fields to base64 and unmarshals the result with jsonpb
+func unmarshalJSONDocument(data []byte, document proto.Message) error {
+	var raw interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err := decoder.Decode(&raw)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode JSON document")
+	}
+	err = convertHexHashedFields(raw, proto.MessageReflect(document).Descriptor())
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return jsonpb.Unmarshal(bytes.NewReader(data), document)
+}
+
+// convertHexHashedFields replaces hex strings of hashed fields in the decoded JSON object value by their base64
+// encoding, nested messages are converted recursively
+func convertHexHashedFields(value interface{}, md protoreflect.MessageDescriptor) error {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for name, v := range object {
+		fd := md.Fields().ByJSONName(name)
+		if fd == nil {
+			fd = md.Fields().ByName(protoreflect.Name(name))
+		}
+		if fd == nil {
+			continue
+		}
+
+		isHashed, err := proto.GetExtension(fieldOptions(fd).Options, proofspb.E_HashedField)
+		if err == nil && *(isHashed.(*bool)) && fd.Kind() == protoreflect.BytesKind {
+			object[name], err = hexToBase64(v)
+			if err != nil {
+				return errors.Wrapf(err, "field %s", name)
+			}
+			continue
+		}
+
+		fieldMD := fd.Message()
+		if fd.IsMap() {
+			fieldMD = fd.MapValue().Message()
+		}
+		if fieldMD == nil {
+			continue
+		}
+		var children []interface{}
+		switch c := v.(type) {
+		case []interface{}:
+			children = c
+		case map[string]interface{}:
+			if fd.IsMap() {
+				for _, child := range c {
+					children = append(children, child)
+				}
+			} else {
+				children = []interface{}{c}
+			}
+		}
+		for _, child := range children {
+			err = convertHexHashedFields(child, fieldMD)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hexToBase64 converts a 0x prefixed hex string or a list of them to base64, other values are returned unchanged
+func hexToBase64(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		if !strings.HasPrefix(v, "0x") && !strings.HasPrefix(v, "0X") {
+			return v, nil
+		}
+		b, err := hex.DecodeString(v[2:])
+		if err != nil {
+			return nil, fmt.Errorf("malformed hex value %s: %s", v, err)
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, e := range v {
+			var err error
+			converted[i], err = hexToBase64(e)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return converted, nil
+	}
+	return value, nil
+}
+
+// addLeavesFrom flattens a document with the salts of the tree, the salt store or the salts of the document provided
+// by newCollector and adds the resulting leaves
+func (doctree *DocumentTree) addLeavesFrom(newCollector func() (*saltCollector, error), flatten func(f *messageFlattener, salts Salts) ([]LeafNode, error)) (err error) {
 	if doctree.hash == nil {
 		return fmt.Errorf("hash is not set")
 	}
 	var salts Salts
+	var collector *saltCollector
 	if doctree.salts != nil {
 		salts = doctree.salts
+	} else if doctree.saltStore != nil {
+		stored, err := doctree.saltStore.Load(doctree.saltStoreDocID)
+		if err != nil {
+			return err
+		}
+		collector = &saltCollector{index: make(map[string][]byte), stored: stored}
+		salts = collector.getSalt
 	} else {
-		var err error
-		salts, err = defaultGetSalt(document)
+		collector, err = newCollector()
 		if err != nil {
 			return err
 		}
+		salts = collector.getSalt
+	}
+	if collector != nil {
+		collector.length = doctree.saltLength
 	}
 
-	leaves, err := FlattenMessage(document, salts, doctree.readablePropertyLengthSuffix, doctree.leafHash, doctree.compactProperties, doctree.parentPrefix, doctree.fixedLengthFieldLeftPadding)
-
+	leaves, err := flatten(doctree.newFlattener(), salts)
 	if err != nil {
 		return err
 	}
+	if collector != nil && doctree.saltStore != nil {
+		err = collector.saveTo(doctree.saltStore, doctree.saltStoreDocID)
+		if err != nil {
+			return err
+		}
+	} else if collector != nil {
+		err = collector.fillBack()
+		if err != nil {
+			return err
+		}
+	}
 	return doctree.AddLeaves(leaves)
 }
 
+// newFlattener returns a messageFlattener configured with the options of the tree
+func (doctree *DocumentTree) newFlattener() *messageFlattener {
+	return &messageFlattener{
+		readablePropertyLengthSuffix: doctree.readablePropertyLengthSuffix,
+		hash:                         doctree.leafHash,
+		compactProperties:            doctree.compactProperties,
+		fixedLengthFieldLeftPadding:  doctree.fixedLengthFieldLeftPadding,
+		sortByCompact:                doctree.sortByCompact,
+		leafTransform:                doctree.leafTransform,
+		strictEnums:                  doctree.strictEnums,
+		includeUnknownFields:         doctree.includeUnknownFields,
+		evmEncoding:                  doctree.evmEncoding,
+		lengthEncoder:                doctree.lengthEncoder,
+		omitZeroLengthLeaves:         doctree.omitZeroLengthLeaves,
+		deriveLengthSalts:            doctree.deriveLengthSalts,
+		typeTagValues:                doctree.typeTagValues,
+		enumAsString:                 doctree.enumAsString,
+		excludeFields:                doctree.excludeFields,
+		hashValuesOver:               doctree.hashValuesOver,
+		useJSONNames:                 doctree.useJSONNames,
+		normalizeNameCase:            doctree.normalizeNameCase,
+		saltLength:                   doctree.saltLength,
+		hashSalt:                     doctree.hashSalt,
+		schemeVersion:                doctree.schemeVersion,
+		anyResolver:                  doctree.anyResolver,
+	}
+}
+
 func fillBackSalts(message proto.Message, saltsSlice []*proofspb.Salt) (err error) {
 	value := reflect.ValueOf(message).Elem().FieldByName(SaltsFieldName)
 	if value == reflect.ValueOf(nil) {
@@ -555,10 +1325,24 @@ func (doctree *DocumentTree) Generate() error {
 		return errors.New("tree already filled")
 	}
 
+	if doctree.commitLeafCount {
+		count := make([]byte, 8)
+		binary.BigEndian.PutUint64(count, uint64(len(doctree.leaves)+1))
+		err := doctree.AddLeaf(LeafNode{Property: LeafCountProp, Value: count})
+		if err != nil {
+			return err
+		}
+	}
+
 	hashes := make([][]byte, len(doctree.leaves))
 	for i, leaf := range doctree.leaves {
 		if len(leaf.Hash) < 1 || leaf.Hashed {
-			err := leaf.HashNode(doctree.leafHash, doctree.compactProperties)
+			err := leaf.hashNode(doctree.leafHash, doctree.compactProperties, doctree.hashSalt, doctree.saltLength, doctree.schemeVersion)
+			if err != nil {
+				return err
+			}
+		} else if doctree.verifyCachedLeafHashes {
+			err := doctree.verifyCachedHash(leaf)
 			if err != nil {
 				return err
 			}
@@ -567,33 +1351,230 @@ func (doctree *DocumentTree) Generate() error {
 		hashes[i] = leaf.Hash
 	}
 
+	if doctree.padToPowerOfTwo {
+		emptyHash, err := emptyNodeHash(doctree.leafHash)
+		if err != nil {
+			return err
+		}
+		doctree.merkleTree = newSparseTree(emptyHash, doctree.hash, doctree.nodeHasher)
+		doctree.fixedNoOfLeafs = 1
+		for doctree.fixedNoOfLeafs < uint(len(hashes)) {
+			doctree.fixedNoOfLeafs <<= 1
+		}
+	}
+
 	err := doctree.merkleTree.Generate(hashes, int(doctree.fixedNoOfLeafs))
 	if err != nil {
 		return fmt.Errorf("failed to generate merkle tree: %s", err)
 	}
 
 	doctree.rootHash = doctree.merkleTree.RootHash()
+	if sibling := doctree.singleLeafSibling(); sibling != nil {
+		doctree.rootHash = doctree.nodeHasher(doctree.rootHash, sibling, doctree.hash)
+	}
 	doctree.filled = true
 	return nil
 }
 
-// GetLeaves returns the leaves of the doc tree.
-func (doctree *DocumentTree) GetLeaves() LeafList {
-	return doctree.leaves
+// verifyCachedHash recomputes the hash of a leaf with a cached hash and compares both
+func (doctree *DocumentTree) verifyCachedHash(leaf LeafNode) error {
+	recomputed := leaf
+	recomputed.Hash = nil
+	err := recomputed.hashNode(doctree.leafHash, doctree.compactProperties, doctree.hashSalt, doctree.saltLength, doctree.schemeVersion)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(recomputed.Hash, leaf.Hash) {
+		return fmt.Errorf("cached hash %x of leaf %s doesn't match its value", leaf.Hash, leaf.Property.ReadableName())
+	}
+	return nil
 }
 
-// GetLeafByProperty returns a leaf if it is found
-func (doctree *DocumentTree) GetLeafByProperty(prop string) (int, *LeafNode) {
-	for index, leaf := range doctree.leaves {
-		if leaf.Property.ReadableName() == prop {
-			return index, &leaf
+// singleLeafSibling returns the sibling the only leaf of the tree is hashed with to calculate the root according to the
+// SingleLeafRootMode, or nil if the root is the leaf hash itself
+func (doctree *DocumentTree) singleLeafSibling() []byte {
+	if len(doctree.leaves) != 1 || doctree.fixedNoOfLeafs > 1 {
+		return nil
+	}
+	switch doctree.singleLeafRootMode {
+	case SingleLeafRootHashOfLeaf:
+		return []byte{}
+	case SingleLeafRootDuplicateAndHash:
+		return doctree.merkleTree.RootHash()
+	}
+	return nil
+}
+
+// EnsureGenerated generates the merkle root if the tree is not filled yet and returns it. Unlike Generate it can be
+// called multiple times, subsequent calls return the already generated root.
+func (doctree *DocumentTree) EnsureGenerated() ([]byte, error) {
+	if !doctree.filled {
+		err := doctree.Generate()
+		if err != nil {
+			return nil, err
 		}
 	}
-	return 0, nil
+	return doctree.rootHash, nil
 }
 
-// GetCompactPropByPropertyName returns a leaf compact name if it is found
-func (doctree *DocumentTree) GetCompactPropByPropertyName(prop string) []byte {
+// VerifyAgainstStoredRoot generates the merkle root if needed and checks it against a previously stored root, e.g.
+// to audit that a regenerated document still has the same root. The error names both roots if they differ.
+func (doctree *DocumentTree) VerifyAgainstStoredRoot(expected []byte) error {
+	root, err := doctree.EnsureGenerated()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, expected) {
+		return fmt.Errorf("root %x does not match the stored root %x", root, expected)
+	}
+	return nil
+}
+
+// ConsistencyProof proves that the leaves of a tree are the first leaves of a larger tree, like the consistency proofs
+// of RFC 6962. It contains the roots of the subtrees needed to calculate both roots from each other.
+type ConsistencyProof [][]byte
+
+// Extend returns a new tree with the leaves of this tree followed by newLeaves, e.g. for append-only documents, and a
+// ConsistencyProof that the leaves of this tree are a prefix of the new tree. The tree has to be generated, the new
+// tree is generated with the same options. Trees with a fixed TreeDepth or hash sorting can't be extended as adding
+// leaves changes their layout.
+func (doctree *DocumentTree) Extend(newLeaves []LeafNode) (DocumentTree, ConsistencyProof, error) {
+	if !doctree.filled {
+		return DocumentTree{}, nil, errors.New("Can't extend a tree before generating merkle root")
+	}
+	if doctree.fixedNoOfLeafs != 0 || doctree.enableHashSorting {
+		return DocumentTree{}, nil, errors.New("trees with a fixed TreeDepth or hash sorting can't be extended")
+	}
+	if doctree.arity > 2 {
+		return DocumentTree{}, nil, errors.New("trees with an Arity above 2 can't be extended")
+	}
+	if doctree.singleLeafSibling() != nil {
+		return DocumentTree{}, nil, errors.New("single leaf trees with a SingleLeafRootMode can't be extended")
+	}
+	if doctree.commitLeafCount {
+		return DocumentTree{}, nil, errors.New("trees with CommitLeafCount can't be extended")
+	}
+
+	extended := *doctree
+	extended.merkleTree = newUnbalancedTree(doctree.hash, doctree.nodeHasher, false)
+	extended.leaves = append([]LeafNode(nil), doctree.leaves...)
+	extended.nameIndex = make(map[string]Property, len(doctree.nameIndex))
+	for k, v := range doctree.nameIndex {
+		extended.nameIndex[k] = v
+	}
+	extended.propertyIndex = make(map[string]string, len(doctree.propertyIndex))
+	for k, v := range doctree.propertyIndex {
+		extended.propertyIndex[k] = v
+	}
+	extended.filled = false
+	extended.rootHash = nil
+
+	err := extended.AddLeaves(newLeaves)
+	if err != nil {
+		return DocumentTree{}, nil, err
+	}
+	err = extended.Generate()
+	if err != nil {
+		return DocumentTree{}, nil, err
+	}
+	proof, err := extended.merkleTree.(*unbalancedTree).consistencyProof(len(doctree.leaves))
+	if err != nil {
+		return DocumentTree{}, nil, err
+	}
+	return extended, proof, nil
+}
+
+// GetLeaves returns the leaves of the doc tree.
+func (doctree *DocumentTree) GetLeaves() LeafList {
+	return doctree.leaves
+}
+
+// GetLeafByProperty returns a leaf if it is found
+func (doctree *DocumentTree) GetLeafByProperty(prop string) (int, *LeafNode) {
+	for index, leaf := range doctree.leaves {
+		if leaf.Property.ReadableName() == prop {
+			return index, &leaf
+		}
+	}
+	return 0, nil
+}
+
+// RedactedLeaf holds what the owner of a document needs to reveal a redacted field later. Only the hash is published
+// with the tree, the salt has to be kept secret along with the value, as both together allow to recompute the hash.
+type RedactedLeaf struct {
+	Property Property
+	Hash     []byte
+	Salt     []byte
+}
+
+// Redact replaces the leaf of the given property by a hashed leaf with the same hash, so the root stays verifiable
+// while the value is withheld. Proofs of the redacted leaf contain its hash instead of the value. Reveal restores the
+// value.
+func (doctree *DocumentTree) Redact(prop string) (RedactedLeaf, error) {
+	index, leaf := doctree.GetLeafByProperty(prop)
+	if leaf == nil {
+		return RedactedLeaf{}, fmt.Errorf("No such field: %s in obj", prop)
+	}
+	if leaf.Hashed {
+		return RedactedLeaf{}, fmt.Errorf("%s is already a hashed leaf", prop)
+	}
+
+	hashed := *leaf
+	err := hashed.hashNode(doctree.leafHash, doctree.compactProperties, doctree.hashSalt, doctree.saltLength, doctree.schemeVersion)
+	if err != nil {
+		return RedactedLeaf{}, err
+	}
+	doctree.leaves[index] = LeafNode{Property: leaf.Property, Hash: hashed.Hash, Hashed: true}
+	return RedactedLeaf{Property: leaf.Property, Hash: hashed.Hash, Salt: leaf.Salt}, nil
+}
+
+// Reveal restores the value of a leaf redacted by Redact. The value has to result in the redacted hash together with
+// the salt of the RedactedLeaf.
+func (doctree *DocumentTree) Reveal(redacted RedactedLeaf, value []byte) error {
+	index, leaf := doctree.GetLeafByProperty(redacted.Property.ReadableName())
+	if leaf == nil || !leaf.Hashed || !bytes.Equal(leaf.Hash, redacted.Hash) {
+		return fmt.Errorf("%s is not redacted", redacted.Property.ReadableName())
+	}
+
+	revealed := LeafNode{Property: redacted.Property, Value: value, Salt: redacted.Salt}
+	err := revealed.hashNode(doctree.leafHash, doctree.compactProperties, doctree.hashSalt, doctree.saltLength, doctree.schemeVersion)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(revealed.Hash, redacted.Hash) {
+		return fmt.Errorf("revealed value doesn't match the redacted hash of %s", redacted.Property.ReadableName())
+	}
+	doctree.leaves[index] = revealed
+	return nil
+}
+
+// LeafPreimage returns the inputs of ConcatValues for the leaf of the given property, so another service can calculate
+// the leaf hash independently: the value, the salt and the encoded property name. If HashSalt is set, the hash of the
+// salt is returned as that is what gets concatenated. The SchemeVersion byte, if set, precedes the concatenation.
+// Hashed leaves have no preimage.
+func (doctree *DocumentTree) LeafPreimage(prop string) (value, salt []byte, propName []byte, err error) {
+	_, leaf := doctree.GetLeafByProperty(prop)
+	if leaf == nil {
+		return nil, nil, nil, fmt.Errorf("No such field: %s in obj", prop)
+	}
+	if leaf.Hashed {
+		return nil, nil, nil, fmt.Errorf("%s is a hashed leaf without preimage", prop)
+	}
+	salt, err = extendSalt(leaf.Property.Name(doctree.compactProperties), leaf.Salt, doctree.saltLength)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if doctree.hashSalt && len(salt) > 0 {
+		salt, err = sum(doctree.leafHash, salt)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return leaf.Value, salt, AsBytes(leaf.Property.Name(doctree.compactProperties)), nil
+}
+
+// GetCompactPropByPropertyName returns a leaf compact name if it is found
+func (doctree *DocumentTree) GetCompactPropByPropertyName(prop string) []byte {
 	for _, leaf := range doctree.leaves {
 		if leaf.Property.ReadableName() == prop {
 			return leaf.Property.CompactName()
@@ -621,6 +1602,76 @@ func (doctree *DocumentTree) PropertyOrder() []Property {
 	return propOrder
 }
 
+// ProvablePaths returns the readable names of all leaves of a doctree in order, including the indexes and keys of the
+// elements of repeated and map fields. Each of them can be passed to CreateProof.
+func (doctree *DocumentTree) ProvablePaths() []string {
+	paths := make([]string, len(doctree.leaves))
+	for i, leaf := range doctree.leaves {
+		paths[i] = leaf.Property.ReadableName()
+	}
+	return paths
+}
+
+// CompactNames returns the compact names of all leaves of a doctree in order
+func (doctree *DocumentTree) CompactNames() [][]byte {
+	compactNames := make([][]byte, len(doctree.leaves))
+	for i, leaf := range doctree.leaves {
+		compactNames[i] = leaf.Property.CompactName()
+	}
+	return compactNames
+}
+
+// SchemaCommitment returns a hash over the compact names of all leaves in tree order, leaving out values and salts.
+// Documents of the same schema share the commitment as long as their repeated and map fields have the same number of
+// elements.
+func (doctree *DocumentTree) SchemaCommitment() []byte {
+	defer doctree.hash.Reset()
+	length := make([]byte, 4)
+	for _, leaf := range doctree.leaves {
+		compact := leaf.Property.CompactName()
+		binary.BigEndian.PutUint32(length, uint32(len(compact)))
+		doctree.hash.Write(length)
+		doctree.hash.Write(compact)
+	}
+	return doctree.hash.Sum(nil)
+}
+
+// LeaksPosition returns true if the proofs of the tree reveal the position of their leaf, which is the case for all
+// trees without sorted hashes as their proofs contain the left/right designation of each hash.
+func (doctree *DocumentTree) LeaksPosition() bool {
+	return !doctree.enableHashSorting
+}
+
+// Warnings lists the properties of the tree that leak information about the document through its proofs, or that
+// make its proofs hard to verify elsewhere, so services can enforce a policy on them.
+func (doctree *DocumentTree) Warnings() []string {
+	var warnings []string
+	if doctree.LeaksPosition() {
+		warnings = append(warnings, "proofs reveal the position of their leaf as hash sorting is disabled")
+	}
+	if doctree.fixedNoOfLeafs == 0 {
+		warnings = append(warnings, "proofs reveal the approximate number of leaves as the tree depth is not fixed")
+	}
+	var unsalted int
+	for _, leaf := range doctree.leaves {
+		// the leaf count is public, its leaf is not salted on purpose
+		if doctree.commitLeafCount && leaf.Property.ReadableName() == LeafCountProp.ReadableName() {
+			continue
+		}
+		if !leaf.Hashed && len(leaf.Salt) == 0 {
+			unsalted++
+		}
+	}
+	if unsalted > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d leaves are not salted and can be brute forced", unsalted))
+	}
+	if doctree.hash != nil && doctree.leafHash != nil && doctree.hash.Size() != doctree.leafHash.Size() {
+		warnings = append(warnings, fmt.Sprintf("leaf hashes have %d bytes but node hashes have %d bytes",
+			doctree.leafHash.Size(), doctree.hash.Size()))
+	}
+	return warnings
+}
+
 // IsEmpty returns false if the tree contains no leaves
 func (doctree *DocumentTree) IsEmpty() bool {
 	return len(doctree.leaves) == 0
@@ -630,255 +1681,1505 @@ func (doctree *DocumentTree) RootHash() []byte {
 	return doctree.rootHash
 }
 
-// CreateProof takes a property in dot notation and returns a Proof object for the given field
+// AllNodeHashes returns the hashes of all nodes of the generated tree level by level, starting with the leaves and
+// ending with the root. For trees with a fixed depth, nodes that only have empty leaves below them are left out.
+// Returns nil if the tree has not been generated.
+func (doctree *DocumentTree) AllNodeHashes() [][]byte {
+	tree, ok := doctree.merkleTree.(interface{ allNodes() [][]byte })
+	if !ok || !doctree.filled {
+		return nil
+	}
+	return tree.allNodes()
+}
+
+// CreateProof takes a property in dot notation and returns a Proof object for the given field. If no field has the
+// given readable name, a hex encoded compact name prefixed with `0x` is accepted as well.
 func (doctree *DocumentTree) CreateProof(prop string) (proof proofspb.Proof, err error) {
 	if doctree.IsEmpty() || !doctree.filled {
 		err = fmt.Errorf("Can't create proof before generating merkle root")
 		return
 	}
 
+	index, leaf := doctree.GetLeafByProperty(prop)
+	if leaf == nil && strings.HasPrefix(prop, "0x") {
+		if compact, err := hex.DecodeString(prop[2:]); err == nil {
+			return doctree.CreateProofWithCompactProp(compact)
+		}
+	}
+	if leaf == nil {
+		return proofspb.Proof{}, fmt.Errorf("No such field: %s in obj", prop)
+	}
+
+	return doctree.createProof(index, leaf)
+}
+
+// CreateLeafCountProof returns the proof of the LeafCountProp leaf of a tree with CommitLeafCount. Its value is the
+// total number of leaves of the tree as a big endian uint64.
+func (doctree *DocumentTree) CreateLeafCountProof() (proofspb.Proof, error) {
+	if !doctree.commitLeafCount {
+		return proofspb.Proof{}, errors.New("the tree doesn't commit its leaf count, set CommitLeafCount")
+	}
+	if doctree.IsEmpty() || !doctree.filled {
+		return proofspb.Proof{}, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+	index, leaf := doctree.GetLeafByCompactProperty(LeafCountProp.CompactName())
+	if leaf == nil {
+		return proofspb.Proof{}, fmt.Errorf("No such field: %s in obj", LeafCountProp.ReadableName())
+	}
+	return doctree.createProof(index, leaf)
+}
+
+// CreateProofWithAncestry returns the proof of prop followed by the proofs of the length leaves of the repeated and
+// map fields it is an element of, starting with the outermost one. Together they show the position of the element
+// within its collections, e.g. that `valueC[1].valueA` belongs to the second element of a 2-element list.
+func (doctree *DocumentTree) CreateProofWithAncestry(prop string) ([]proofspb.Proof, error) {
+	if doctree.IsEmpty() || !doctree.filled {
+		return nil, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+	index, leaf := doctree.GetLeafByProperty(prop)
+	if leaf == nil {
+		return nil, fmt.Errorf("No such field: %s in obj", prop)
+	}
+
+	indexes := []int{index}
+	var ancestors []int
+	for p := &leaf.Property; p.Parent != nil; p = p.Parent {
+		if p.NameFormat != ElemFormat {
+			continue
+		}
+		lengthName := fmt.Sprintf(SubFieldFormat, p.Parent.ReadableName(), doctree.readablePropertyLengthSuffix)
+		lengthIndex, lengthLeaf := doctree.GetLeafByProperty(lengthName)
+		if lengthLeaf == nil {
+			return nil, fmt.Errorf("No such field: %s in obj", lengthName)
+		}
+		ancestors = append([]int{lengthIndex}, ancestors...)
+	}
+	indexes = append(indexes, ancestors...)
+
+	proofs := make([]proofspb.Proof, len(indexes))
+	for i, idx := range indexes {
+		var err error
+		proofs[i], err = doctree.createProof(idx, &doctree.leaves[idx])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proofs, nil
+}
+
+// CreateProofsParallel creates the proofs of props with the given number of goroutines and returns them in the order of
+// props. Creating proofs only reads the generated tree, so it is safe to do concurrently, unlike validating proofs,
+// which uses the hash functions of the tree.
+func CreateProofsParallel(tree *DocumentTree, props []string, workers int) ([]proofspb.Proof, error) {
+	if workers < 1 {
+		return nil, fmt.Errorf("workers has to be at least 1, got %d", workers)
+	}
+	if tree.IsEmpty() || !tree.filled {
+		return nil, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+
+	proofs := make([]proofspb.Proof, len(props))
+	errs := make([]error, len(props))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				proofs[i], errs[i] = tree.CreateProof(props[i])
+			}
+		}()
+	}
+	for i := range props {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proofs, nil
+}
+
+// CreateRepeatedFieldProofs returns the proofs for all elements of a repeated or map field, ordered by their index or
+// compact key. Elements that are messages contribute a proof for each of their leaves. The length leaf of the field is
+// not included, it can be proven with CreateProof.
+func (doctree *DocumentTree) CreateRepeatedFieldProofs(field string) ([]proofspb.Proof, error) {
+	if doctree.IsEmpty() || !doctree.filled {
+		return nil, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+	if _, ok := doctree.nameIndex[fmt.Sprintf(SubFieldFormat, field, doctree.readablePropertyLengthSuffix)]; !ok {
+		return nil, fmt.Errorf("No such repeated field: %s in obj", field)
+	}
+
+	type elemLeaf struct {
+		elem  []byte
+		index int
+	}
+	var elems []elemLeaf
+	for i, leaf := range doctree.leaves {
+		for p := &leaf.Property; p.Parent != nil; p = p.Parent {
+			if p.NameFormat == ElemFormat && p.Parent.ReadableName() == field {
+				elems = append(elems, elemLeaf{elem: p.Compact, index: i})
+				break
+			}
+		}
+	}
+	sort.SliceStable(elems, func(i, j int) bool {
+		return bytes.Compare(elems[i].elem, elems[j].elem) < 0
+	})
+
+	proofs := make([]proofspb.Proof, len(elems))
+	for i, e := range elems {
+		var err error
+		proofs[i], err = doctree.createProof(e.index, &doctree.leaves[e.index])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return proofs, nil
+}
+
+// CreateProofMatchesHash creates a proof for the given property like CreateProof and reports whether the hash of its
+// leaf equals expectedLeafHash, e.g. a commitment to the value that is known separately.
+func (doctree *DocumentTree) CreateProofMatchesHash(prop string, expectedLeafHash []byte) (proof proofspb.Proof, matches bool, err error) {
+	proof, err = doctree.CreateProof(prop)
+	if err != nil {
+		return
+	}
+
+	leafHash := proof.Hash
+	if len(leafHash) == 0 {
+		var input []byte
+		input, err = concatLeafValues(doctree.leafPropertyName(proof.Property), proof.Value, proof.Salt, doctree.leafHash, doctree.hashSalt, doctree.saltLength, doctree.schemeVersion)
+		if err != nil {
+			return
+		}
+		leafHash = hashBytes(doctree.leafHash, input)
+	}
+	matches = bytes.Equal(leafHash, expectedLeafHash)
+	return
+}
+
+// SelectiveDisclosure contains the proofs of the revealed fields of a document together with the root they validate
+// against. The fields that are not revealed only appear as salted leaf hashes in the proofs.
+type SelectiveDisclosure struct {
+	RootHash []byte
+	Sorted   bool
+	Proofs   []*proofspb.Proof
+}
+
+// Validate validates every proof of the disclosure against its root. The leaves and inner nodes are hashed with
+// hashFunc and HashTwoValues, like ValidateProofHashes does.
+func (d SelectiveDisclosure) Validate(hashFunc hash.Hash) error {
+	for _, proof := range d.Proofs {
+		_, _, err := ValidateProofAnyRoot(proof, [][]byte{d.RootHash}, hashFunc, d.Sorted)
+		if err != nil {
+			return errors.Wrapf(err, "invalid proof for %s", proof.Property)
+		}
+	}
+	return nil
+}
+
+// CreateSelectiveDisclosure creates the proofs for the revealed fields. An error is returned if a field that is not
+// revealed has no salt, as its value could be recovered from its leaf hash by brute force.
+func (doctree *DocumentTree) CreateSelectiveDisclosure(reveal []string) (SelectiveDisclosure, error) {
+	if doctree.IsEmpty() || !doctree.filled {
+		return SelectiveDisclosure{}, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+
+	disclosure := SelectiveDisclosure{RootHash: doctree.rootHash, Sorted: doctree.enableHashSorting}
+	revealed := make(map[int]struct{}, len(reveal))
+	for _, prop := range reveal {
+		index, leaf := doctree.GetLeafByProperty(prop)
+		if leaf == nil {
+			return SelectiveDisclosure{}, fmt.Errorf("No such field: %s in obj", prop)
+		}
+		proof, err := doctree.createProof(index, leaf)
+		if err != nil {
+			return SelectiveDisclosure{}, err
+		}
+		disclosure.Proofs = append(disclosure.Proofs, &proof)
+		revealed[index] = struct{}{}
+	}
+
+	for i, leaf := range doctree.leaves {
+		if _, ok := revealed[i]; ok || leaf.Hashed || len(leaf.Salt) > 0 {
+			continue
+		}
+		return SelectiveDisclosure{}, fmt.Errorf("%s is not salted and could be recovered from the disclosure", leaf.Property.ReadableName())
+	}
+	return disclosure, nil
+}
+
+// CreateProofWithCompactProp takes a property in compact form and returns a Proof object for the given field
+func (doctree *DocumentTree) CreateProofWithCompactProp(prop []byte) (proof proofspb.Proof, err error) {
+	if doctree.IsEmpty() || !doctree.filled {
+		err = fmt.Errorf("Can't create proof before generating merkle root")
+		return
+	}
+
+	index, leaf := doctree.GetLeafByCompactProperty(prop)
+	if leaf == nil {
+		return proofspb.Proof{}, fmt.Errorf("No such field: %x in obj", prop)
+	}
+
+	return doctree.createProof(index, leaf)
+}
+
+// CreateProofByHexPath takes a compact property as hex string, with or without 0x prefix, and returns a Proof object
+// for the given field together with the readable name of the field, so callers can confirm which field the path
+// resolves to.
+func (doctree *DocumentTree) CreateProofByHexPath(hexPath string) (proof proofspb.Proof, name string, err error) {
+	if doctree.IsEmpty() || !doctree.filled {
+		err = fmt.Errorf("Can't create proof before generating merkle root")
+		return
+	}
+
+	compact, err := hex.DecodeString(strings.TrimPrefix(hexPath, "0x"))
+	if err != nil {
+		err = fmt.Errorf("malformed hex path %s: %s", hexPath, err)
+		return
+	}
+	index, leaf := doctree.GetLeafByCompactProperty(compact)
+	if leaf == nil {
+		err = fmt.Errorf("No such field: %x in obj", compact)
+		return
+	}
+
+	proof, err = doctree.createProof(index, leaf)
+	if err != nil {
+		return
+	}
+	name = leaf.Property.ReadableName()
+	return
+}
+
+// CreateProofWithFieldNums returns a Proof object for the field with the given compact path, as returned by
+// Property.FieldNums. As the components of a compact name don't all have the same size, the path is compared to the
+// paths of the leaves instead of being encoded into a compact name. An error is returned if several leaves match.
+func (doctree *DocumentTree) CreateProofWithFieldNums(fn *proofspb.FieldNums) (proofspb.Proof, error) {
+	if doctree.IsEmpty() || !doctree.filled {
+		return proofspb.Proof{}, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+
+	index := -1
+	for i, leaf := range doctree.leaves {
+		leafNums, err := leaf.Property.FieldNums()
+		if err != nil || !reflect.DeepEqual(leafNums.Components, fn.Components) {
+			continue
+		}
+		if index != -1 {
+			return proofspb.Proof{}, fmt.Errorf("field nums %v match several fields", fn.Components)
+		}
+		index = i
+	}
+	if index == -1 {
+		return proofspb.Proof{}, fmt.Errorf("No such field: %v in obj", fn.Components)
+	}
+
+	return doctree.createProof(index, &doctree.leaves[index])
+}
+
+// CreateUniformProof takes a property in dot notation and returns a Proof object for the given field. It requires a
+// fixed depth tree (see TreeOptions.TreeDepth) so that all proofs have the same number of hashes independently of
+// the number of fields of the document, hiding the field count from the verifier.
+func (doctree *DocumentTree) CreateUniformProof(prop string) (proof proofspb.Proof, err error) {
+	if doctree.fixedNoOfLeafs == 0 {
+		return proofspb.Proof{}, errors.New("uniform proofs require a fixed depth tree")
+	}
+	if doctree.IsEmpty() || !doctree.filled {
+		return proofspb.Proof{}, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+
 	index, leaf := doctree.GetLeafByProperty(prop)
 	if leaf == nil {
 		return proofspb.Proof{}, fmt.Errorf("No such field: %s in obj", prop)
 	}
 
-	return doctree.createProof(index, leaf)
+	hashes, err := doctree.pickHashesFromMerkleTree(uint64(index))
+	if err != nil {
+		return proofspb.Proof{}, err
+	}
+	depth := bits.TrailingZeros(doctree.fixedNoOfLeafs)
+	if len(hashes) != depth {
+		return proofspb.Proof{}, fmt.Errorf("proof for %s has %d hashes instead of %d", prop, len(hashes), depth)
+	}
+
+	return doctree.createProof(index, leaf)
+}
+
+// CreateEmptyLeafProof returns a proof for the padding leaf at the given index of a fixed depth tree (see
+// TreeOptions.TreeDepth), showing that this slot of the tree is empty. The proof has no property and its Hash is the
+// empty leaf hash.
+func (doctree *DocumentTree) CreateEmptyLeafProof(index int) (proofspb.Proof, error) {
+	if doctree.fixedNoOfLeafs == 0 {
+		return proofspb.Proof{}, errors.New("empty leaf proofs require a fixed depth tree")
+	}
+	if doctree.IsEmpty() || !doctree.filled {
+		return proofspb.Proof{}, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+	if index < len(doctree.leaves) {
+		return proofspb.Proof{}, fmt.Errorf("leaf %d is not empty", index)
+	}
+	if index >= int(doctree.fixedNoOfLeafs) {
+		return proofspb.Proof{}, fmt.Errorf("leaf %d is out of range", index)
+	}
+
+	emptyHash, err := emptyNodeHash(doctree.leafHash)
+	if err != nil {
+		return proofspb.Proof{}, err
+	}
+	hashes, err := doctree.pickHashesFromMerkleTree(uint64(index))
+	if err != nil {
+		return proofspb.Proof{}, err
+	}
+	return proofspb.Proof{Hash: emptyHash, Hashes: hashes}, nil
+}
+
+// CreateProofAsPrefix returns a proof for the given property in which the tree's ParentPrefix is replaced by
+// newPrefix. This is only possible for leaves whose hash doesn't include the property name, i.e. fields with the
+// hashed_field option; for all other leaves an error is returned as the proof would not validate. The returned proof
+// still validates against the root of this tree.
+func (doctree *DocumentTree) CreateProofAsPrefix(prop string, newPrefix Property) (proofspb.Proof, error) {
+	if doctree.IsEmpty() || !doctree.filled {
+		return proofspb.Proof{}, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+
+	index, leaf := doctree.GetLeafByProperty(prop)
+	if leaf == nil {
+		return proofspb.Proof{}, fmt.Errorf("No such field: %s in obj", prop)
+	}
+	if !leaf.Hashed {
+		return proofspb.Proof{}, fmt.Errorf("can't change the prefix of %s as the property name is part of its leaf hash", prop)
+	}
+
+	levels := propertyDepth(leaf.Property) - propertyDepth(doctree.parentPrefix)
+	if levels < 1 {
+		return proofspb.Proof{}, fmt.Errorf("%s is not below the prefix of the tree", prop)
+	}
+	reprefixed := *leaf
+	reprefixed.Property = reparentProperty(leaf.Property, levels, newPrefix)
+	return doctree.createProof(index, &reprefixed)
+}
+
+// CreateMapEntryProof returns the Proof for the element with the given key of a map field, or of a repeated field with
+// the mapping_key option, together with the compact encoding of the key. The readable name of the proof only contains
+// the key as text, with the compact key a verifier can confirm the encoding independently. Only elements with a
+// single leaf can be proven.
+func (doctree *DocumentTree) CreateMapEntryProof(field string, key interface{}) (proof proofspb.Proof, compactKey []byte, err error) {
+	if doctree.IsEmpty() || !doctree.filled {
+		err = fmt.Errorf("Can't create proof before generating merkle root")
+		return
+	}
+
+	for index, leaf := range doctree.leaves {
+		prop := leaf.Property
+		if prop.Parent == nil || prop.NameFormat != ElemFormat || prop.Parent.ReadableName() != field {
+			continue
+		}
+		// the compact key of the element is padded to the key length of the field
+		readableKey, compact, keyErr := keyNames(key, uint64(len(prop.Compact)))
+		if keyErr != nil || readableKey != prop.Text || !bytes.Equal(compact, prop.Compact) {
+			continue
+		}
+		proof, err = doctree.createProof(index, &leaf)
+		if err != nil {
+			return
+		}
+		compactKey = compact
+		return
+	}
+	err = fmt.Errorf("No such field: %s[%v] in obj", field, key)
+	return
+}
+
+// CreateAppendFieldProof takes the property of a leaf created with the append_fields option and returns a Proof for
+// the merged leaf together with the fields it is made of, so a verifier can split the proven value into its fields.
+// Leaves whose merged value was changed by a LeafTransform or hashed because of HashValuesOver can't be split and
+// are rejected.
+func (doctree *DocumentTree) CreateAppendFieldProof(parent string) (proof *proofspb.Proof, components []AppendedField, err error) {
+	if doctree.IsEmpty() || !doctree.filled {
+		return nil, nil, fmt.Errorf("Can't create proof before generating merkle root")
+	}
+
+	index, leaf := doctree.GetLeafByProperty(parent)
+	if leaf == nil {
+		return nil, nil, fmt.Errorf("No such field: %s in obj", parent)
+	}
+	if leaf.Components == nil {
+		return nil, nil, fmt.Errorf("%s is not created from appended fields or its value was transformed or hashed", parent)
+	}
+
+	p, err := doctree.createProof(index, leaf)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &p, append([]AppendedField(nil), leaf.Components...), nil
+}
+
+func (doctree *DocumentTree) createProof(index int, leaf *LeafNode) (proof proofspb.Proof, err error) {
+	propName := doctree.proofPropertyName(leaf.Property)
+	proof = proofspb.Proof{
+		Property: propName,
+		Value:    leaf.Value,
+		Salt:     leaf.Salt,
+	}
+
+	if leaf.Hashed {
+		proof.Hash = leaf.Hash
+	}
+
+	sibling := doctree.singleLeafSibling()
+	if doctree.enableHashSorting {
+		sortedHashes, err := doctree.pickHashesFromMerkleTreeAsList(uint64(index))
+		if err != nil {
+			return proofspb.Proof{}, err
+		}
+		if sibling != nil {
+			sortedHashes = append(sortedHashes, sibling)
+		}
+		proof.SortedHashes = sortedHashes
+	} else {
+		hashes, err := doctree.pickHashesFromMerkleTree(uint64(index))
+		if err != nil {
+			return proofspb.Proof{}, err
+		}
+		if sibling != nil {
+			hashes = append(hashes, &proofspb.MerkleHash{Right: sibling})
+		}
+		proof.Hashes = hashes
+	}
+	return proof, nil
+}
+
+func (doctree *DocumentTree) pickHashesFromMerkleTree(leaf uint64) (hashes []*proofspb.MerkleHash, err error) {
+	if tree, ok := doctree.merkleTree.(*karyTree); ok {
+		return tree.proofSteps(leaf)
+	}
+	proofNodes, err := doctree.merkleTree.GetMerkleProof(uint(leaf))
+	if err != nil {
+		return hashes, err
+	}
+
+	hashes = make([]*proofspb.MerkleHash, len(proofNodes))
+
+	for i, _ := range proofNodes {
+		n := proofNodes[i]
+		h := n.Hash
+		if n.Left {
+			hashes[i] = &proofspb.MerkleHash{Left: h, Right: nil}
+		} else {
+			hashes[i] = &proofspb.MerkleHash{Left: nil, Right: h}
+
+		}
+	}
+	return hashes, nil
+}
+
+// pickHashesListFromMerkleTree takes the required hashes needed to create a proof as a list
+func (doctree *DocumentTree) pickHashesFromMerkleTreeAsList(leaf uint64) (hashes [][]byte, err error) {
+	proofNodes, err := doctree.merkleTree.GetMerkleProof(uint(leaf))
+	if err != nil {
+		return hashes, err
+	}
+
+	hashes = make([][]byte, len(proofNodes))
+	for i, _ := range proofNodes {
+		hashes[i] = proofNodes[i].Hash
+	}
+	return
+}
+
+// proofPropertyName returns the name of the property as used in proofs, without the parent prefix if
+// StripPrefixInProof is set.
+func (doctree *DocumentTree) proofPropertyName(prop Property) proofspb.PropertyName {
+	name := prop.Name(doctree.compactProperties)
+	if !doctree.stripPrefixInProof {
+		return name
+	}
+	switch n := name.(type) {
+	case *proofspb.Proof_ReadableName:
+		if prefix := doctree.parentPrefix.ReadableName(); prefix != "" {
+			return ReadableName(strings.TrimPrefix(n.ReadableName, fmt.Sprintf(SubFieldFormat, prefix, "")))
+		}
+	case *proofspb.Proof_CompactName:
+		return CompactName(bytes.TrimPrefix(n.CompactName, doctree.parentPrefix.CompactName())...)
+	}
+	return name
+}
+
+// leafPropertyName returns the name of the property as it was hashed into the leaf, re-adding the parent prefix
+// stripped by proofPropertyName.
+func (doctree *DocumentTree) leafPropertyName(name proofspb.PropertyName) proofspb.PropertyName {
+	if !doctree.stripPrefixInProof {
+		return name
+	}
+	switch n := name.(type) {
+	case *proofspb.Proof_ReadableName:
+		if prefix := doctree.parentPrefix.ReadableName(); prefix != "" {
+			return ReadableName(fmt.Sprintf(SubFieldFormat, prefix, n.ReadableName))
+		}
+	case *proofspb.Proof_CompactName:
+		return CompactName(append(doctree.parentPrefix.CompactName(), n.CompactName...)...)
+	}
+	return name
+}
+
+// ValidateProof by comparing it to the tree's rootHash
+func (doctree *DocumentTree) ValidateProof(proof *proofspb.Proof) (valid bool, err error) {
+	var fieldHash []byte
+	if len(proof.Hash) == 0 {
+		var input []byte
+		input, err = concatLeafValues(doctree.leafPropertyName(proof.Property), proof.Value, proof.Salt, doctree.leafHash, doctree.hashSalt, doctree.saltLength, doctree.schemeVersion)
+		if err == nil {
+			fieldHash = hashBytes(doctree.leafHash, input)
+		}
+	} else {
+		fieldHash = proof.Hash
+	}
+	if err != nil {
+		return false, err
+	}
+	if doctree.enableHashSorting {
+		valid, err = validateProofSortedHashes(fieldHash, proof.SortedHashes, doctree.rootHash, doctree.hash, doctree.nodeHasher)
+	} else if doctree.arity > 2 {
+		valid, err = ValidateKaryProofHashes(fieldHash, proof.Hashes, doctree.rootHash, doctree.hash)
+	} else {
+		valid, err = validateProofHashes(fieldHash, proof.Hashes, doctree.rootHash, doctree.hash, doctree.nodeHasher)
+	}
+	return
+}
+
+// ErrValueMismatch is returned by ValidateProofWithValue if the proof is valid but its value differs from the
+// expected one
+var ErrValueMismatch = errors.New("Value does not match")
+
+// ValidateProofWithValue validates the proof like ValidateProof and checks that its value equals expected. The values
+// are compared in constant time. If only the values differ, ErrValueMismatch is returned.
+func (doctree *DocumentTree) ValidateProofWithValue(proof *proofspb.Proof, expected []byte) (bool, error) {
+	valid, err := doctree.ValidateProof(proof)
+	if err != nil || !valid {
+		return valid, err
+	}
+	if subtle.ConstantTimeCompare(proof.Value, expected) != 1 {
+		return false, ErrValueMismatch
+	}
+	return true, nil
+}
+
+// propertyDepth returns the number of properties in the path of the given property, not counting empty roots
+func propertyDepth(prop Property) int {
+	depth := 0
+	for p := &prop; p != nil; p = p.Parent {
+		if p.Parent == nil && p.Text == "" && len(p.Compact) == 0 {
+			break
+		}
+		depth++
+	}
+	return depth
+}
+
+// reparentProperty returns a copy of prop with the ancestor the given number of levels up replaced by newParent
+func reparentProperty(prop Property, levels int, newParent Property) Property {
+	if levels == 1 {
+		prop.Parent = &newParent
+		return prop
+	}
+	parent := reparentProperty(*prop.Parent, levels-1, newParent)
+	prop.Parent = &parent
+	return prop
+}
+
+// LeafNode represents a field that can be hashed to create a merkle tree
+type LeafNode struct {
+	Property Property
+	Value    []byte
+	Salt     []byte
+	// Hash contains either the hash that is calculated from Value, Salt & Property or a user defined hash
+	Hash []byte
+	// If set to true, the the value added to the tree is LeafNode.Hash instead of the hash calculated from Value, Salt
+	// & Property
+	Hashed bool
+	// Components lists the fields merged into Value if the leaf was created with the append_fields option
+	Components []AppendedField
+}
+
+// AppendedField describes a field merged into a leaf by the append_fields option. Value[Start:End] of the leaf holds
+// the (padded) value of the field. The ranges refer to the value before a LeafTransform is applied.
+type AppendedField struct {
+	Name     string
+	FieldNum FieldNum
+	Start    int
+	End      int
+}
+
+// HashNode calculates the hash of a node provided it isn't already calculated.
+func (n *LeafNode) HashNode(h hash.Hash, compact bool) error {
+	return n.hashNode(h, compact, false, 0, 0)
+}
+
+// hashNode calculates the hash of a node like HashNode, using the hash of the salt if hashSalt is set, extending
+// shorter salts to saltLength if it is set and prepending the scheme version if it is set
+func (n *LeafNode) hashNode(h hash.Hash, compact bool, hashSalt bool, saltLength int, version byte) error {
+	if len(n.Hash) > 0 || n.Hashed {
+		return nil
+	}
+
+	payload, err := concatLeafValues(n.Property.Name(compact), n.Value, n.Salt, h, hashSalt, saltLength, version)
+	if err != nil {
+		return err
+	}
+
+	n.Hash, err = sum(h, payload)
+	return err
+}
+
+// ConcatValues concatenates property, value & salt into one byte slice.
+func ConcatValues(propName proofspb.PropertyName, value []byte, salt []byte) (payload []byte, err error) {
+	payload = append(payload, AsBytes(propName)...)
+	payload = append(payload, []byte(value)...)
+	if len(salt) > 0 && len(salt) != 32 {
+		return []byte{}, fmt.Errorf("%s: Salt has incorrect length: %d instead of 32", propName, len(salt))
+	}
+	payload = append(payload, salt...)
+	return
+}
+
+// concatLeafValues concatenates property, value & salt like ConcatValues. If saltLength is set, shorter salts are
+// extended to saltLength. If hashSalt is set, the hash of the salt is used instead of the salt, which can have any
+// length then. A non-zero version is prepended to the payload.
+func concatLeafValues(propName proofspb.PropertyName, value []byte, salt []byte, hashFunc hash.Hash, hashSalt bool, saltLength int, version byte) ([]byte, error) {
+	if version != 0 {
+		payload, err := concatLeafValues(propName, value, salt, hashFunc, hashSalt, saltLength, 0)
+		if err != nil {
+			return []byte{}, err
+		}
+		return append([]byte{version}, payload...), nil
+	}
+	if len(salt) == 0 || (!hashSalt && saltLength == 0) {
+		return ConcatValues(propName, value, salt)
+	}
+	salt, err := extendSalt(propName, salt, saltLength)
+	if err != nil {
+		return []byte{}, err
+	}
+	if hashSalt {
+		salt, err = sum(hashFunc, salt)
+		if err != nil {
+			return []byte{}, err
+		}
+	}
+	payload := append([]byte{}, AsBytes(propName)...)
+	payload = append(payload, value...)
+	return append(payload, salt...), nil
+}
+
+// extendSalt appends zeros to salts shorter than saltLength, salts are returned unchanged if saltLength is not set
+func extendSalt(propName proofspb.PropertyName, salt []byte, saltLength int) ([]byte, error) {
+	if saltLength == 0 || len(salt) == 0 {
+		return salt, nil
+	}
+	if len(salt) > saltLength {
+		return nil, fmt.Errorf("%s: Salt has incorrect length: %d instead of at most %d", propName, len(salt), saltLength)
+	}
+	return append(append(make([]byte, 0, saltLength), salt...), make([]byte, saltLength-len(salt))...), nil
+}
+
+// LeafList is a list implementation that can be sorted by the LeafNode.Property value. This is needed for ordering all
+// leaves before generating a merkleTree out of it.
+type LeafList []LeafNode
+
+// Len returns the length of the list
+func (s LeafList) Len() int {
+	return len(s)
+}
+
+// Swap two items in the list
+func (s LeafList) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+type sortByReadableName struct{ LeafList }
+
+// Compare by property name lexicographically
+func (m sortByReadableName) Less(i, j int) bool {
+	return strings.Compare(string(m.LeafList[i].Property.ReadableName()), string(m.LeafList[j].Property.ReadableName())) == -1
+}
+
+type sortByCompactName struct{ LeafList }
+
+// Compare by property compact name
+func (m sortByCompactName) Less(i, j int) bool {
+	return bytes.Compare(AsBytes(m.LeafList[i].Property.Name(true)), AsBytes(m.LeafList[j].Property.Name(true))) == -1
+}
+
+// HashTwoValues concatenate two hashes to calculate hash out of the result. This is used in the merkleTree calculation code
+// as well as the validation code.
+func HashTwoValues(a []byte, b []byte, hashFunc hash.Hash) (hash []byte) {
+	data := make([]byte, len(a)+len(b))
+	copy(data[:len(a)], a)
+	copy(data[len(a):], b)
+	return hashBytes(hashFunc, data)
+}
+
+// NewKeccakHasher returns a keccak256 hash as used by Ethereum, which uses the legacy keccak padding and differs from
+// the standardized SHA3-256.
+func NewKeccakHasher() hash.Hash {
+	return sha3.NewLegacyKeccak256()
+}
+
+// hashAlgos maps the names used in AnnotatedProof.HashAlgo to constructors of the hash functions
+var hashAlgos = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"blake2b-256": func() hash.Hash {
+		h, _ := blake2b.New256(nil)
+		return h
+	},
+	"keccak256": NewKeccakHasher,
+}
+
+// RegisterHashAlgo makes a hash function available under the given name for NewHashByName and annotated proofs
+func RegisterHashAlgo(name string, newHash func() hash.Hash) {
+	hashAlgos[name] = newHash
+}
+
+// NewHashByName returns a new hash function for one of the names "sha256", "blake2b-256", "keccak256" or a name
+// registered with RegisterHashAlgo
+func NewHashByName(name string) (hash.Hash, error) {
+	newHash, ok := hashAlgos[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown hash algorithm %q", name)
+	}
+	return newHash(), nil
+}
+
+// ValidateAnnotatedProof validates the proof of an AnnotatedProof against the root, using the hash algorithm declared
+// by the proof for both the leaf and the inner nodes
+func ValidateAnnotatedProof(annotated *proofspb.AnnotatedProof, rootHash []byte, sorted bool) (valid bool, err error) {
+	hashFunc, err := NewHashByName(annotated.HashAlgo)
+	if err != nil {
+		return false, err
+	}
+	proof := annotated.Proof
+	if proof == nil {
+		return false, errors.New("annotated proof has no proof")
+	}
+
+	fieldHash := proof.Hash
+	if len(fieldHash) == 0 {
+		fieldHash, err = CalculateHashForProofField(proof, hashFunc)
+		if err != nil {
+			return false, err
+		}
+	}
+	if sorted {
+		return ValidateProofSortedHashes(fieldHash, proof.SortedHashes, rootHash, hashFunc)
+	}
+	return ValidateProofHashes(fieldHash, proof.Hashes, rootHash, hashFunc)
+}
+
+// ValidateTypedAnnotatedProof works like ValidateTypedProof, using the hash algorithm declared by the proof
+func ValidateTypedAnnotatedProof(typedValue interface{}, typ string, annotated *proofspb.AnnotatedProof, rootHash []byte, sorted bool) (valid bool, err error) {
+	hashFunc, err := NewHashByName(annotated.HashAlgo)
+	if err != nil {
+		return false, err
+	}
+	if annotated.Proof == nil {
+		return false, errors.New("annotated proof has no proof")
+	}
+	return ValidateTypedProof(typedValue, typ, annotated.Proof, rootHash, hashFunc, sorted)
+}
+
+// DecodeJSONProofsStream decodes a bundle of proofs of the form
+//
+//	{"document_root": "<base64>", "field_proofs": [<proof>, ...]}
+//
+// incrementally, calling fn for every proof as soon as it is decoded, and returns the document root. Proofs are in the
+// protobuf JSON format. Decoding stops at the first error returned by fn. Unknown keys are skipped.
+func DecodeJSONProofsStream(r io.Reader, fn func(*proofspb.Proof) error) ([]byte, error) {
+	dec := json.NewDecoder(r)
+	err := expectJSONDelim(dec, '{')
+	if err != nil {
+		return nil, err
+	}
+
+	var root []byte
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch tok {
+		case "document_root", "documentRoot":
+			err = dec.Decode(&root)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to decode document root")
+			}
+		case "field_proofs", "fieldProofs":
+			err = expectJSONDelim(dec, '[')
+			if err != nil {
+				return nil, err
+			}
+			for i := 0; dec.More(); i++ {
+				var raw json.RawMessage
+				err = dec.Decode(&raw)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to read proof %d", i)
+				}
+				proof := new(proofspb.Proof)
+				err = jsonpb.Unmarshal(bytes.NewReader(raw), proof)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to decode proof %d", i)
+				}
+				err = fn(proof)
+				if err != nil {
+					return nil, err
+				}
+			}
+			err = expectJSONDelim(dec, ']')
+			if err != nil {
+				return nil, err
+			}
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return root, expectJSONDelim(dec, '}')
+}
+
+func expectJSONDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("expected %s but got %v", delim, tok)
+	}
+	return nil
+}
+
+// hashBytes takes a hash.Hash interface and hashes the provided value
+func hashBytes(hashFunc hash.Hash, input []byte) []byte {
+	hash, err := sum(hashFunc, input)
+	if err != nil {
+		return []byte{}
+	}
+	return hash
+}
+
+// sha256Type is the type of the hash.Hash returned by sha256.New
+var sha256Type = reflect.TypeOf(sha256.New())
+
+// sum hashes the input with a reset hashFunc. sha256 is detected and hashed with sha256.Sum256, which avoids the
+// interface calls and allocations of the generic path. The result is identical.
+func sum(hashFunc hash.Hash, input []byte) ([]byte, error) {
+	if reflect.TypeOf(hashFunc) == sha256Type && hashFunc.Size() == sha256.Size {
+		sum := sha256.Sum256(input)
+		return sum[:], nil
+	}
+
+	defer hashFunc.Reset()
+	_, err := hashFunc.Write(input)
+	if err != nil {
+		return nil, err
+	}
+	return hashFunc.Sum(nil), nil
+}
+
+type HashNode struct {
+	Left bool
+	Leaf uint64
+}
+
+// CalculateProofNodeList returns the list of nodes that are needed to calculate the root from the given leaf in a
+// tree with leafCount leaves. The nodes are identified by their index in the list of all nodes of the tree, starting
+// with the leaves and ending with the root.
+func CalculateProofNodeList(node, leafCount uint64) (nodes []*HashNode, err error) {
+	if node >= leafCount {
+		return nil, errors.New("node index is too big for node count")
+	}
+
+	height, _ := calculateHeightAndNodeCount(leafCount)
+	lastNodeInLevel := leafCount - 1
+	offset := uint64(0)
+
+	for level := height - 1; level > 0; level-- {
+		// only add hash if this isn't an odd end
+		if !(node == lastNodeInLevel && (lastNodeInLevel+1)%2 == 1) {
+			if node%2 == 0 {
+				nodes = append(nodes, &HashNode{false, offset + node + 1})
+			} else {
+				nodes = append(nodes, &HashNode{true, offset + node - 1})
+			}
+		}
+		node = node / 2
+		offset += lastNodeInLevel + 1
+		lastNodeInLevel = (lastNodeInLevel+1)/2 + (lastNodeInLevel+1)%2 - 1
+	}
+	return nodes, nil
+}
+
+// ProofCoverage returns the sorted indices of all nodes a proof for the given leaf touches: the siblings provided
+// by the proof as well as the nodes on the path from the leaf up to the root. The indices follow the same layout as
+// CalculateProofNodeList.
+func ProofCoverage(leafIndex int, leafCount uint64) ([]uint64, error) {
+	if leafIndex < 0 {
+		return nil, errors.New("node index can't be negative")
+	}
+	node := uint64(leafIndex)
+	siblings, err := CalculateProofNodeList(node, leafCount)
+	if err != nil {
+		return nil, err
+	}
+
+	var coverage []uint64
+	for _, sibling := range siblings {
+		coverage = append(coverage, sibling.Leaf)
+	}
+
+	height, _ := calculateHeightAndNodeCount(leafCount)
+	lastNodeInLevel := leafCount - 1
+	offset := uint64(0)
+	for level := height - 1; level > 0; level-- {
+		coverage = append(coverage, offset+node)
+		node = node / 2
+		offset += lastNodeInLevel + 1
+		lastNodeInLevel = (lastNodeInLevel+1)/2 + (lastNodeInLevel+1)%2 - 1
+	}
+	// root
+	coverage = append(coverage, offset+node)
+
+	sort.Slice(coverage, func(i, j int) bool { return coverage[i] < coverage[j] })
+	return coverage, nil
+}
+
+// ProofsCoverAllLeaves checks whether the given proofs prove every leaf of a tree with leafCount leaves. The position
+// of each leaf is derived from the left/right designation of the proof hashes, so proofs with sorted hashes are not
+// supported. The proofs are not validated against a root, use DocumentTree.ValidateProof for that.
+func ProofsCoverAllLeaves(proofs []*proofspb.Proof, leafCount int) (bool, error) {
+	if leafCount <= 0 {
+		return false, errors.New("leaf count must be positive")
+	}
+	covered := make(map[uint64]struct{}, len(proofs))
+	for i, proof := range proofs {
+		if len(proof.SortedHashes) > 0 {
+			return false, fmt.Errorf("proof %d uses sorted hashes which don't reveal the leaf position", i)
+		}
+		index, err := leafIndexFromHashes(proof.Hashes, uint64(leafCount))
+		if err != nil {
+			return false, errors.Wrapf(err, "proof %d", i)
+		}
+		covered[index] = struct{}{}
+	}
+	return len(covered) == leafCount, nil
+}
+
+// leafIndexFromHashes returns the index of the leaf a list of proof hashes belongs to in a tree with leafCount
+// leaves. The tree is walked from the root down, a lone node at the end of a level has no hash in the proof.
+func leafIndexFromHashes(hashes []*proofspb.MerkleHash, leafCount uint64) (uint64, error) {
+	var levelCounts []uint64
+	for count := leafCount; ; count = (count + 1) / 2 {
+		levelCounts = append(levelCounts, count)
+		if count <= 1 {
+			break
+		}
+	}
+
+	next := len(hashes) - 1
+	node := uint64(0)
+	for level := len(levelCounts) - 2; level >= 0; level-- {
+		node = 2 * node
+		if node+1 >= levelCounts[level] {
+			// lone node, promoted without a sibling
+			continue
+		}
+		if next < 0 {
+			return 0, errors.New("proof has too few hashes for the tree size")
+		}
+		if len(hashes[next].Left) > 0 {
+			node++
+		}
+		next--
+	}
+	if next >= 0 {
+		return 0, errors.New("proof has too many hashes for the tree size")
+	}
+	return node, nil
+}
+
+// LeafIndexFromProof returns the index of the proven leaf in a tree with leafCount leaves, 2**TreeDepth for trees with
+// a fixed TreeDepth. The index is derived from the left/right designation of the proof hashes, taking into account
+// that lone nodes at the end of a level are promoted without a hash in the proof. Proofs with sorted hashes don't
+// reveal the position of the leaf.
+func LeafIndexFromProof(proof *proofspb.Proof, leafCount uint64) (uint64, error) {
+	if len(proof.SortedHashes) > 0 {
+		return 0, errors.New("proof uses sorted hashes which don't reveal the leaf position")
+	}
+	if leafCount == 0 {
+		return 0, errors.New("leaf count must be positive")
+	}
+	return leafIndexFromHashes(proof.Hashes, leafCount)
+}
+
+// calculateHeightAndNodeCount returns the height and number of nodes of a tree with the given number of leaves. The
+// tree is unbalanced on the right side, lone nodes at the end of a level are promoted to the next level.
+func calculateHeightAndNodeCount(leafCount uint64) (height, nodeCount uint64) {
+	for count := leafCount; count > 0; count = (count + 1) / 2 {
+		height++
+		nodeCount += count
+		if count == 1 {
+			break
+		}
+	}
+	return height, nodeCount
+}
+
+// CalculateHashForProofField takes a Proof struct and returns a hash of the concatenated property name, value & salt.
+// Uses ConcatValues internally.
+func CalculateHashForProofField(proof *proofspb.Proof, hashFunc hash.Hash) (hash []byte, err error) {
+	input, err := ConcatValues(proof.Property, proof.Value, proof.Salt)
+	if err != nil {
+		return []byte{}, err
+	}
+	hash = hashBytes(hashFunc, input)
+	return hash, nil
+}
+
+// ValidateProofHashes calculates the merkle root based on a list of left/right hashes.
+func ValidateProofHashes(hash []byte, hashes []*proofspb.MerkleHash, rootHash []byte, hashFunc hash.Hash) (valid bool, err error) {
+	return verify.ProofHashes(hash, hashes, rootHash, hashFunc)
+}
+
+// NodeOrdering returns the order in which a node and its sibling from a proof are concatenated to calculate their
+// parent, e.g. for trees built by implementations with a different child ordering convention
+type NodeOrdering func(node []byte, sibling *proofspb.MerkleHash) (left, right []byte)
+
+// DefaultNodeOrdering places the sibling on the side given by the proof, i.e. on the left if its Left hash is set
+// and on the right otherwise. ValidateProofHashes uses this ordering.
+func DefaultNodeOrdering(node []byte, sibling *proofspb.MerkleHash) (left, right []byte) {
+	if len(sibling.Left) == 0 {
+		return node, sibling.Right
+	}
+	return sibling.Left, node
+}
+
+// ValidateProofHashesWithOrdering calculates the merkle root based on a list of left/right hashes like
+// ValidateProofHashes, concatenating each node with its sibling in the order given by ordering
+func ValidateProofHashesWithOrdering(hash []byte, hashes []*proofspb.MerkleHash, rootHash []byte, hashFunc hash.Hash, ordering NodeOrdering) (valid bool, err error) {
+	for i := 0; i < len(hashes); i++ {
+		left, right := ordering(hash, hashes[i])
+		hash = HashTwoValues(left, right, hashFunc)
+	}
+	if !bytes.Equal(hash, rootHash) {
+		return false, errors.New("Hash does not match")
+	}
+
+	return true, nil
+}
+
+func validateProofHashes(hash []byte, hashes []*proofspb.MerkleHash, rootHash []byte, hashFunc hash.Hash, nodeHasher NodeHasher) (valid bool, err error) {
+	hash = proofRoot(hash, hashes, nil, false, hashFunc, nodeHasher, nil)
+	if !bytes.Equal(hash, rootHash) {
+		return false, errors.New("Hash does not match")
+	}
+
+	return true, nil
 }
 
-// CreateProofWithCompactProp takes a property in compact form and returns a Proof object for the given field
-func (doctree *DocumentTree) CreateProofWithCompactProp(prop []byte) (proof proofspb.Proof, err error) {
-	if doctree.IsEmpty() || !doctree.filled {
-		err = fmt.Errorf("Can't create proof before generating merkle root")
-		return
+// ValidateKaryProofHashes calculates the merkle root of a tree with an Arity above 2 based on a list of steps, each
+// holding the concatenated siblings left and right of the node. The parent is the hash of the concatenation of the
+// left siblings, the node and the right siblings. Proofs of binary trees using the default NodeHasher validate as
+// well.
+func ValidateKaryProofHashes(hash []byte, hashes []*proofspb.MerkleHash, rootHash []byte, hashFunc hash.Hash) (valid bool, err error) {
+	for i := 0; i < len(hashes); i++ {
+		group := make([]byte, 0, len(hashes[i].Left)+len(hash)+len(hashes[i].Right))
+		group = append(append(append(group, hashes[i].Left...), hash...), hashes[i].Right...)
+		hash = hashBytes(hashFunc, group)
 	}
-
-	index, leaf := doctree.GetLeafByCompactProperty(prop)
-	if leaf == nil {
-		return proofspb.Proof{}, fmt.Errorf("No such field: %x in obj", prop)
+	if !bytes.Equal(hash, rootHash) {
+		return false, errors.New("Hash does not match")
 	}
 
-	return doctree.createProof(index, leaf)
+	return true, nil
 }
 
-func (doctree *DocumentTree) createProof(index int, leaf *LeafNode) (proof proofspb.Proof, err error) {
-	propName := leaf.Property.Name(doctree.compactProperties)
-	proof = proofspb.Proof{
-		Property: propName,
-		Value:    leaf.Value,
-		Salt:     leaf.Salt,
-	}
+// ValidateProofSortedHashes calculates the merkle root based on a list of sorted hashes.
+func ValidateProofSortedHashes(hash []byte, hashes [][]byte, rootHash []byte, hashFunc hash.Hash) (valid bool, err error) {
+	return verify.SortedHashes(hash, hashes, rootHash, hashFunc)
+}
 
-	if leaf.Hashed {
-		proof.Hash = leaf.Hash
+func validateProofSortedHashes(hash []byte, hashes [][]byte, rootHash []byte, hashFunc hash.Hash, nodeHasher NodeHasher) (valid bool, err error) {
+	hash = proofRoot(hash, nil, hashes, true, hashFunc, nodeHasher, nil)
+	if !bytes.Equal(hash, rootHash) {
+		return false, errors.New("Hash does not match")
 	}
 
-	if doctree.enableHashSorting {
-		sortedHashes, err := doctree.pickHashesFromMerkleTreeAsList(uint64(index))
-		if err != nil {
-			return proofspb.Proof{}, err
+	return true, nil
+}
+
+// proofStep is called by proofRoot for every sibling the node is combined with, telling whether the sibling is the
+// left child and the resulting parent
+type proofStep func(sibling []byte, left bool, parent []byte)
+
+// proofRoot calculates the root of a proof from the hash of its leaf. If sorted is set, the node is combined with each
+// of sortedHashes in the order of their values, otherwise with each of hashes on the side given by the proof. step
+// is optional.
+func proofRoot(hash []byte, hashes []*proofspb.MerkleHash, sortedHashes [][]byte, sorted bool, hashFunc hash.Hash, nodeHasher NodeHasher, step proofStep) []byte {
+	combine := func(sibling []byte, left bool) {
+		if left {
+			hash = nodeHasher(sibling, hash, hashFunc)
+		} else {
+			hash = nodeHasher(hash, sibling, hashFunc)
 		}
-		proof.SortedHashes = sortedHashes
-	} else {
-		hashes, err := doctree.pickHashesFromMerkleTree(uint64(index))
-		if err != nil {
-			return proofspb.Proof{}, err
+		if step != nil {
+			step(sibling, left, hash)
 		}
-		proof.Hashes = hashes
 	}
-	return proof, nil
-}
 
-func (doctree *DocumentTree) pickHashesFromMerkleTree(leaf uint64) (hashes []*proofspb.MerkleHash, err error) {
-	proofNodes, err := doctree.merkleTree.GetMerkleProof(uint(leaf))
-	if err != nil {
-		return hashes, err
+	if sorted {
+		for _, sibling := range sortedHashes {
+			combine(sibling, bytes.Compare(hash, sibling) > 0)
+		}
+		return hash
 	}
 
-	hashes = make([]*proofspb.MerkleHash, len(proofNodes))
-
-	for i, _ := range proofNodes {
-		n := proofNodes[i]
-		h := n.Hash
-		if n.Left {
-			hashes[i] = &proofspb.MerkleHash{Left: h, Right: nil}
+	for _, sibling := range hashes {
+		if len(sibling.Left) == 0 {
+			combine(sibling.Right, false)
 		} else {
-			hashes[i] = &proofspb.MerkleHash{Left: nil, Right: h}
-
+			combine(sibling.Left, true)
 		}
 	}
-	return hashes, nil
+	return hash
 }
 
-// pickHashesListFromMerkleTree takes the required hashes needed to create a proof as a list
-func (doctree *DocumentTree) pickHashesFromMerkleTreeAsList(leaf uint64) (hashes [][]byte, err error) {
-	proofNodes, err := doctree.merkleTree.GetMerkleProof(uint(leaf))
-	if err != nil {
-		return hashes, err
+// VerifyConsistency checks a ConsistencyProof created by DocumentTree.Extend, i.e. that the tree with newRoot and
+// newSize leaves starts with the oldSize leaves of the tree with oldRoot. The trees have to use the default
+// NodeHasher.
+func VerifyConsistency(oldRoot, newRoot []byte, proof ConsistencyProof, oldSize, newSize int, hashFunc hash.Hash) (bool, error) {
+	return verifyConsistency(oldRoot, newRoot, proof, oldSize, newSize, hashFunc, HashTwoValues)
+}
+
+// VerifyProofSalt reports whether the salt of the proof equals expectedSalt, e.g. if salts are committed to separately.
+// The comparison is done in constant time.
+func VerifyProofSalt(proof *proofspb.Proof, expectedSalt []byte) bool {
+	return subtle.ConstantTimeCompare(proof.Salt, expectedSalt) == 1
+}
+
+// ValidateProofAnyRoot validates the proof against several candidate roots, e.g. while roots are rotated. The root
+// is calculated once and the index of the first matching root is returned, or -1 if none matches. If sorted is set,
+// the sorted hashes of the proof are used.
+func ValidateProofAnyRoot(proof *proofspb.Proof, roots [][]byte, hashFunc hash.Hash, sorted bool) (int, bool, error) {
+	fieldHash := proof.Hash
+	if len(fieldHash) == 0 {
+		var err error
+		fieldHash, err = CalculateHashForProofField(proof, hashFunc)
+		if err != nil {
+			return -1, false, err
+		}
 	}
 
-	hashes = make([][]byte, len(proofNodes))
-	for i, _ := range proofNodes {
-		hashes[i] = proofNodes[i].Hash
+	root := proofRoot(fieldHash, proof.Hashes, proof.SortedHashes, sorted, hashFunc, HashTwoValues, nil)
+	for i, rootHash := range roots {
+		if bytes.Equal(root, rootHash) {
+			return i, true, nil
+		}
 	}
-	return
+	return -1, false, errors.New("Hash does not match")
 }
 
-// ValidateProof by comparing it to the tree's rootHash
-func (doctree *DocumentTree) ValidateProof(proof *proofspb.Proof) (valid bool, err error) {
-	var fieldHash []byte
-	if len(proof.Hash) == 0 {
-		fieldHash, err = CalculateHashForProofField(proof, doctree.leafHash)
-	} else {
-		fieldHash = proof.Hash
-	}
+// ValidateProofByBinaryPath validates a proof whose property is given as field number path, see ProofToBinaryPath, for
+// a document of msgType. The readable property name is derived from the path, so it only validates proofs of trees
+// using readable names. If sorted is set, sortedHashes are used, hashes otherwise.
+func ValidateProofByBinaryPath(msgType reflect.Type, binaryPath []uint64, value, salt, root []byte, hashes []*proofspb.MerkleHash, sortedHashes [][]byte, hashFunc hash.Hash, sorted bool) (bool, error) {
+	prop, err := binaryPathToProperty(msgType, binaryPath)
 	if err != nil {
 		return false, err
 	}
-	if doctree.enableHashSorting {
-		valid, err = ValidateProofSortedHashes(fieldHash, proof.SortedHashes, doctree.rootHash, doctree.hash)
-	} else {
-		valid, err = ValidateProofHashes(fieldHash, proof.Hashes, doctree.rootHash, doctree.hash)
+	proof := &proofspb.Proof{
+		Property:     ReadableName(prop.ReadableName()),
+		Value:        value,
+		Salt:         salt,
+		Hashes:       hashes,
+		SortedHashes: sortedHashes,
 	}
-	return
-}
-
-// LeafNode represents a field that can be hashed to create a merkle tree
-type LeafNode struct {
-	Property Property
-	Value    []byte
-	Salt     []byte
-	// Hash contains either the hash that is calculated from Value, Salt & Property or a user defined hash
-	Hash []byte
-	// If set to true, the the value added to the tree is LeafNode.Hash instead of the hash calculated from Value, Salt
-	// & Property
-	Hashed bool
+	_, valid, err := ValidateProofAnyRoot(proof, [][]byte{root}, hashFunc, sorted)
+	return valid, err
 }
 
-// HashNode calculates the hash of a node provided it isn't already calculated.
-func (n *LeafNode) HashNode(h hash.Hash, compact bool) error {
-	if len(n.Hash) > 0 || n.Hashed {
-		return nil
+// VerifyNestedProof verifies a proof of a field of an inner document whose root is committed in a field of an outer
+// document: innerProof has to validate against innerRoot, the value or hash of outerProof has to be innerRoot and
+// outerProof has to validate against outerRoot. Proofs with sorted hashes are validated as such.
+func VerifyNestedProof(innerProof *proofspb.Proof, innerRoot []byte, outerProof *proofspb.Proof, outerRoot []byte, hashFunc hash.Hash) (bool, error) {
+	_, valid, err := ValidateProofAnyRoot(innerProof, [][]byte{innerRoot}, hashFunc, len(innerProof.SortedHashes) > 0)
+	if !valid {
+		return false, errors.Wrap(err, "inner proof")
 	}
 
-	payload, err := ConcatValues(n.Property.Name(compact), n.Value, n.Salt)
-	if err != nil {
-		return err
+	committed := outerProof.Value
+	if len(outerProof.Hash) > 0 {
+		committed = outerProof.Hash
 	}
-
-	defer h.Reset()
-	_, err = h.Write(payload)
-	if err != nil {
-		return err
+	if !bytes.Equal(committed, innerRoot) {
+		return false, errors.New("outer proof doesn't commit to the inner root")
 	}
-	n.Hash = h.Sum(nil)
-	return nil
-}
 
-// ConcatValues concatenates property, value & salt into one byte slice.
-func ConcatValues(propName proofspb.PropertyName, value []byte, salt []byte) (payload []byte, err error) {
-	payload = append(payload, AsBytes(propName)...)
-	payload = append(payload, []byte(value)...)
-	if len(salt) > 0 && len(salt) != 32 {
-		return []byte{}, fmt.Errorf("%s: Salt has incorrect length: %d instead of 32", propName, len(salt))
+	_, valid, err = ValidateProofAnyRoot(outerProof, [][]byte{outerRoot}, hashFunc, len(outerProof.SortedHashes) > 0)
+	if !valid {
+		return false, errors.Wrap(err, "outer proof")
 	}
-	payload = append(payload, salt...)
-	return
-}
-
-// LeafList is a list implementation that can be sorted by the LeafNode.Property value. This is needed for ordering all
-// leaves before generating a merkleTree out of it.
-type LeafList []LeafNode
-
-// Len returns the length of the list
-func (s LeafList) Len() int {
-	return len(s)
-}
-
-// Swap two items in the list
-func (s LeafList) Swap(i, j int) {
-	s[i], s[j] = s[j], s[i]
+	return true, nil
 }
 
-type sortByReadableName struct{ LeafList }
-
-// Compare by property name lexicographically
-func (m sortByReadableName) Less(i, j int) bool {
-	return strings.Compare(string(m.LeafList[i].Property.ReadableName()), string(m.LeafList[j].Property.ReadableName())) == -1
-}
+// VerifyProofWithTrace validates the proof against the root like ValidateProofHashes and ValidateProofSortedHashes and
+// additionally returns a human readable log of every step: the hash of the leaf, one line per sibling hash it is
+// combined with and the resulting root. If sorted is set, the sorted hashes of the proof are used.
+func VerifyProofWithTrace(proof *proofspb.Proof, root []byte, hashFunc hash.Hash, sorted bool) (bool, []string, error) {
+	var trace []string
+	hash := proof.Hash
+	if len(hash) > 0 {
+		trace = append(trace, fmt.Sprintf("use hash of hashed leaf %s = %x", traceName(proof), hash))
+	} else {
+		var err error
+		hash, err = CalculateHashForProofField(proof, hashFunc)
+		if err != nil {
+			return false, trace, err
+		}
+		trace = append(trace, fmt.Sprintf("hash leaf %s = %x", traceName(proof), hash))
+	}
 
-type sortByCompactName struct{ LeafList }
+	kind := "sibling"
+	if sorted {
+		kind = "sorted sibling"
+	}
+	hash = proofRoot(hash, proof.Hashes, proof.SortedHashes, sorted, hashFunc, HashTwoValues, func(sibling []byte, left bool, parent []byte) {
+		side := "right"
+		if left {
+			side = "left"
+		}
+		trace = append(trace, fmt.Sprintf("combine with %s %x (%s) = %x", kind, sibling, side, parent))
+	})
 
-// Compare by property compact name
-func (m sortByCompactName) Less(i, j int) bool {
-	return bytes.Compare(AsBytes(m.LeafList[i].Property.Name(true)), AsBytes(m.LeafList[j].Property.Name(true))) == -1
+	if !bytes.Equal(hash, root) {
+		trace = append(trace, fmt.Sprintf("final root = %x does not match %x", hash, root))
+		return false, trace, errors.New("Hash does not match")
+	}
+	trace = append(trace, fmt.Sprintf("final root = %x matches", hash))
+	return true, trace, nil
 }
 
-// HashTwoValues concatenate two hashes to calculate hash out of the result. This is used in the merkleTree calculation code
-// as well as the validation code.
-func HashTwoValues(a []byte, b []byte, hashFunc hash.Hash) (hash []byte) {
-	data := make([]byte, len(a)+len(b))
-	copy(data[:len(a)], a)
-	copy(data[len(a):], b)
-	return hashBytes(hashFunc, data)
+// traceName returns the property name of a proof for VerifyProofWithTrace, compact names are hex encoded
+func traceName(proof *proofspb.Proof) string {
+	if compact := proof.GetCompactName(); compact != nil {
+		return fmt.Sprintf("%x", compact)
+	}
+	return proof.GetReadableName()
 }
 
-// hashBytes takes a hash.Hash interface and hashes the provided value
-func hashBytes(hashFunc hash.Hash, input []byte) []byte {
-	defer hashFunc.Reset()
-	_, err := hashFunc.Write(input[:])
+// ValidateTypedProof validates a proof whose value is given as a typed scalar instead of its encoded bytes, as it
+// happens for human authored proofs. The value is encoded with EncodeValue before hashing. The property, salt and
+// hashes are taken from the given proof while its value is ignored. If sorted is set, the sorted hashes of the proof
+// are used.
+func ValidateTypedProof(typedValue interface{}, typ string, proof *proofspb.Proof, rootHash []byte, hashFunc hash.Hash, sorted bool) (valid bool, err error) {
+	value, err := EncodeValue(typedValue, typ)
 	if err != nil {
-		return []byte{}
+		return false, err
+	}
+	input, err := ConcatValues(proof.Property, value, proof.Salt)
+	if err != nil {
+		return false, err
 	}
-	return hashFunc.Sum(nil)
+	fieldHash := hashBytes(hashFunc, input)
+	if sorted {
+		return ValidateProofSortedHashes(fieldHash, proof.SortedHashes, rootHash, hashFunc)
+	}
+	return ValidateProofHashes(fieldHash, proof.Hashes, rootHash, hashFunc)
 }
 
-type HashNode struct {
-	Left bool
-	Leaf uint64
+// SortedHashesValidator validates sorted hash proofs like ValidateProofSortedHashes but reuses its scratch buffers
+// and hash function between calls. It is meant for validating many proofs in a row and is not safe for concurrent use.
+type SortedHashesValidator struct {
+	hashFunc hash.Hash
+	data     []byte
+	sum      []byte
 }
 
-// CalculateHashForProofField takes a Proof struct and returns a hash of the concatenated property name, value & salt.
-// Uses ConcatValues internally.
-func CalculateHashForProofField(proof *proofspb.Proof, hashFunc hash.Hash) (hash []byte, err error) {
-	input, err := ConcatValues(proof.Property, proof.Value, proof.Salt)
-	if err != nil {
-		return []byte{}, err
-	}
-	hash = hashBytes(hashFunc, input)
-	return hash, nil
+// NewSortedHashesValidator returns a SortedHashesValidator using the given hash function
+func NewSortedHashesValidator(hashFunc hash.Hash) *SortedHashesValidator {
+	return &SortedHashesValidator{hashFunc: hashFunc}
 }
 
-// ValidateProofHashes calculates the merkle root based on a list of left/right hashes.
-func ValidateProofHashes(hash []byte, hashes []*proofspb.MerkleHash, rootHash []byte, hashFunc hash.Hash) (valid bool, err error) {
+// Validate calculates the merkle root based on a list of sorted hashes and compares it to rootHash.
+// The result is identical to ValidateProofSortedHashes.
+func (v *SortedHashesValidator) Validate(hash []byte, hashes [][]byte, rootHash []byte) (valid bool, err error) {
+	current := hash
 	for i := 0; i < len(hashes); i++ {
-		if len(hashes[i].Left) == 0 {
-			hash = HashTwoValues(hash, hashes[i].Right, hashFunc)
+		if bytes.Compare(current, hashes[i]) > 0 {
+			v.data = append(append(v.data[:0], hashes[i]...), current...)
 		} else {
-			hash = HashTwoValues(hashes[i].Left, hash, hashFunc)
+			v.data = append(append(v.data[:0], current...), hashes[i]...)
+		}
+		v.hashFunc.Reset()
+		_, err = v.hashFunc.Write(v.data)
+		if err != nil {
+			return false, err
 		}
+		v.sum = v.hashFunc.Sum(v.sum[:0])
+		current = v.sum
 	}
-	if !bytes.Equal(hash, rootHash) {
+	v.hashFunc.Reset()
+
+	if !bytes.Equal(current, rootHash) {
 		return false, errors.New("Hash does not match")
 	}
 
 	return true, nil
 }
 
-// ValidateProofHashes calculates the merkle root based on a list of left/right hashes.
-func ValidateProofSortedHashes(hash []byte, hashes [][]byte, rootHash []byte, hashFunc hash.Hash) (valid bool, err error) {
-	for i := 0; i < len(hashes); i++ {
-		if bytes.Compare(hash, hashes[i]) > 0 {
-			hash = HashTwoValues(hashes[i], hash, hashFunc)
+// StandardToSorted converts a proof with left/right designated Hashes into a proof with SortedHashes. As sorted
+// hashing doesn't depend on the order of two nodes, the left/right designation is dropped.
+//
+// Note that the inner nodes of a tree with sorted hashes differ from the ones of a standard tree unless every left
+// node happens to be the smaller one. A converted proof therefore only validates against the root of a sorted tree
+// built from the same leaves if the proof consists of leaf hashes only (e.g. trees with two leaves) or the orders
+// match. The reverse conversion isn't possible without knowing the position of the leaf in the tree.
+func StandardToSorted(proof *proofspb.Proof) *proofspb.Proof {
+	sorted := proto.Clone(proof).(*proofspb.Proof)
+	sorted.Hashes = nil
+	sorted.SortedHashes = make([][]byte, len(proof.Hashes))
+	for i, h := range proof.Hashes {
+		if len(h.Left) > 0 {
+			sorted.SortedHashes[i] = h.Left
 		} else {
-			hash = HashTwoValues(hash, hashes[i], hashFunc)
+			sorted.SortedHashes[i] = h.Right
 		}
 	}
+	return sorted
+}
 
-	if !bytes.Equal(hash, rootHash) {
-		return false, errors.New("Hash does not match")
+// ProofsEqual reports whether two proofs are equal. The property, value, salt and hash are compared as well as the
+// hashes, whose order matters for standard proofs while sorted hashes are compared as a set.
+func ProofsEqual(a, b *proofspb.Proof) bool {
+	if !bytes.Equal(AsBytes(a.Property), AsBytes(b.Property)) ||
+		reflect.TypeOf(a.Property) != reflect.TypeOf(b.Property) ||
+		!bytes.Equal(a.Value, b.Value) ||
+		!bytes.Equal(a.Salt, b.Salt) ||
+		!bytes.Equal(a.Hash, b.Hash) {
+		return false
 	}
 
-	return true, nil
+	if len(a.Hashes) != len(b.Hashes) {
+		return false
+	}
+	for i := range a.Hashes {
+		if !bytes.Equal(a.Hashes[i].GetLeft(), b.Hashes[i].GetLeft()) || !bytes.Equal(a.Hashes[i].GetRight(), b.Hashes[i].GetRight()) {
+			return false
+		}
+	}
+
+	if len(a.SortedHashes) != len(b.SortedHashes) {
+		return false
+	}
+	counts := make(map[string]int, len(a.SortedHashes))
+	for _, h := range a.SortedHashes {
+		counts[string(h)]++
+	}
+	for _, h := range b.SortedHashes {
+		if counts[string(h)] == 0 {
+			return false
+		}
+		counts[string(h)]--
+	}
+	return true
+}
+
+// CanonicalizeProof returns a copy of the proof with a deterministic representation, e.g. for signing the marshalled
+// proof. Empty byte fields are set to nil, an empty property is dropped and unknown fields are removed, so proofs that
+// only differ in these encodings result in the same bytes when marshalled deterministically.
+func CanonicalizeProof(p *proofspb.Proof) *proofspb.Proof {
+	canonical := &proofspb.Proof{
+		Value: canonicalBytes(p.Value),
+		Salt:  canonicalBytes(p.Salt),
+		Hash:  canonicalBytes(p.Hash),
+	}
+
+	switch prop := p.Property.(type) {
+	case *proofspb.Proof_ReadableName:
+		if prop.ReadableName != "" {
+			canonical.Property = &proofspb.Proof_ReadableName{ReadableName: prop.ReadableName}
+		}
+	case *proofspb.Proof_CompactName:
+		if len(prop.CompactName) > 0 {
+			canonical.Property = &proofspb.Proof_CompactName{CompactName: canonicalBytes(prop.CompactName)}
+		}
+	}
+
+	for _, h := range p.Hashes {
+		if h == nil {
+			h = &proofspb.MerkleHash{}
+		}
+		canonical.Hashes = append(canonical.Hashes, &proofspb.MerkleHash{Left: canonicalBytes(h.Left), Right: canonicalBytes(h.Right)})
+	}
+	for _, h := range p.SortedHashes {
+		canonical.SortedHashes = append(canonical.SortedHashes, canonicalBytes(h))
+	}
+	return canonical
+}
+
+// canonicalBytes returns a copy of b, or nil if b is empty
+func canonicalBytes(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
+
+// ProofSizeComparison returns the serialized sizes of the proof of prop in the standard mode, with left and right
+// hashes, and in the sorted mode of EnableHashSorting, to help choosing a mode. The proof of the other mode is created
+// from a copy of the generated tree using the same leaves. Trees with a fixed size or an Arity above 2 only support
+// their own mode.
+func ProofSizeComparison(doctree *DocumentTree, prop string) (standardBytes, sortedBytes int, err error) {
+	if !doctree.filled {
+		return 0, 0, errors.New("Can't create proof before generating merkle root")
+	}
+	if doctree.fixedNoOfLeafs != 0 || doctree.arity > 2 {
+		return 0, 0, errors.New("trees with a fixed size or an Arity above 2 only support one proof mode")
+	}
+
+	// the leaves already contain the leaf count leaf, so it is not added again
+	other := *doctree
+	other.enableHashSorting = !doctree.enableHashSorting
+	other.commitLeafCount = false
+	other.leaves = append([]LeafNode(nil), doctree.leaves...)
+	other.merkleTree = newUnbalancedTree(doctree.hash, doctree.nodeHasher, other.enableHashSorting)
+	other.filled = false
+	other.rootHash = nil
+	err = other.Generate()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	proof, err := doctree.CreateProof(prop)
+	if err != nil {
+		return 0, 0, err
+	}
+	otherProof, err := other.CreateProof(prop)
+	if err != nil {
+		return 0, 0, err
+	}
+	if doctree.enableHashSorting {
+		return proto.Size(&otherProof), proto.Size(&proof), nil
+	}
+	return proto.Size(&proof), proto.Size(&otherProof), nil
 }
 
 // OptimizeProofs identifies common hashes to all proofs provided for the same tree and reduces the length of the resulting
@@ -919,3 +3220,93 @@ func OptimizeProofs(proofs []*proofspb.Proof, documentRoot []byte, hashFunc hash
 	}
 	return optimized, nil
 }
+
+// ValidateOptimizedProofs validates proofs returned by OptimizeProofs, in the order they were returned. Each proof
+// has to lead to the root or to a node established by an earlier proof, so the hashes shared by the proofs are only
+// combined once.
+func ValidateOptimizedProofs(proofs []*proofspb.Proof, root []byte, hashFunc hash.Hash) (bool, error) {
+	if len(proofs) == 0 {
+		return false, errors.New("no proofs to validate")
+	}
+	known := map[string]struct{}{string(root): {}}
+	pair := make([]byte, 0, 2*hashFunc.Size())
+	var path [][]byte
+	for i, proof := range proofs {
+		node := proof.Hash
+		if len(node) == 0 {
+			var err error
+			node, err = CalculateHashForProofField(proof, hashFunc)
+			if err != nil {
+				return false, err
+			}
+		}
+		path = append(path[:0], node)
+		_, found := known[string(node)]
+		for j := 0; j < len(proof.SortedHashes) && !found; j++ {
+			sibling := proof.SortedHashes[j]
+			if bytes.Compare(node, sibling) > 0 {
+				pair = append(append(pair[:0], sibling...), node...)
+			} else {
+				pair = append(append(pair[:0], node...), sibling...)
+			}
+			node = hashBytes(hashFunc, pair)
+			path = append(path, sibling, node)
+			_, found = known[string(node)]
+		}
+		if !found {
+			return false, fmt.Errorf("proof %d: Hash does not match", i)
+		}
+		for _, h := range path {
+			known[string(h)] = struct{}{}
+		}
+	}
+	return true, nil
+}
+
+// ProofVerifierCache validates proofs like ValidateProofHashes and ValidateProofSortedHashes, memoizing the parent of
+// every pair of nodes it combines. Proofs of the same document share their upper siblings, so
+// validating a batch of them with one cache only hashes each of those pairs once. A cache must not be used
+// concurrently.
+type ProofVerifierCache struct {
+	hashFunc hash.Hash
+	parents  map[string][]byte
+	key      []byte
+}
+
+// NewProofVerifierCache returns an empty cache for proofs of trees using hashFunc and the default NodeHasher
+func NewProofVerifierCache(hashFunc hash.Hash) *ProofVerifierCache {
+	return &ProofVerifierCache{hashFunc: hashFunc, parents: make(map[string][]byte)}
+}
+
+// ValidateProof calculates the root from the proof, using the memoized parents where possible, and compares it
+// with rootHash. The leaf is hashed with CalculateHashForProofField unless the proof holds the hash of the field.
+func (c *ProofVerifierCache) ValidateProof(proof *proofspb.Proof, rootHash []byte) (bool, error) {
+	node := proof.Hash
+	if len(node) == 0 {
+		var err error
+		node, err = CalculateHashForProofField(proof, c.hashFunc)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	node = proofRoot(node, proof.Hashes, proof.SortedHashes, len(proof.SortedHashes) > 0, c.hashFunc, c.parent, nil)
+	if !bytes.Equal(node, rootHash) {
+		return false, errors.New("Hash does not match")
+	}
+	return true, nil
+}
+
+// parent is the NodeHasher of the cache, it returns the memoized parent of left and right or hashes them
+func (c *ProofVerifierCache) parent(left, right []byte, hashFunc hash.Hash) []byte {
+	key := c.key[:0]
+	key = append(key, byte(len(left)))
+	key = append(append(key, left...), right...)
+	c.key = key
+	if parent, ok := c.parents[string(key)]; ok {
+		return parent
+	}
+	parent := HashTwoValues(left, right, hashFunc)
+	c.parents[string(key)] = parent
+	return parent
+}
@@ -4,9 +4,15 @@ import (
 	"bytes"
 	"crypto/md5"
 	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
+	"reflect"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -14,12 +20,17 @@ import (
 
 	"github.com/centrifuge/precise-proofs/examples/documents"
 	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/xsleonard/go-merkle"
 	"golang.org/x/crypto/blake2b"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 var testSalt = []byte{213, 85, 144, 21, 65, 130, 94, 93, 64, 97, 45, 34, 1, 66, 199, 66, 140, 56, 92, 72, 224, 36, 95, 211, 164, 11, 142, 59, 100, 103, 155, 225}
@@ -179,6 +190,28 @@ func TestDocumentTree_Generate_twice(t *testing.T) {
 	assert.EqualError(t, err, "tree already filled")
 }
 
+func TestDocumentTree_EnsureGenerated(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample)
+	assert.Nil(t, err)
+	root, err := doctree.EnsureGenerated()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, root)
+	assert.Equal(t, doctree.RootHash(), root)
+	rootAgain, err := doctree.EnsureGenerated()
+	assert.Nil(t, err)
+	assert.Equal(t, root, rootAgain)
+
+	// errors of Generate are returned
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.Nil(t, err)
+	err = doctree.AddLeaf(LeafNode{Property: NewProperty("A", 1), Salt: []byte{1}})
+	assert.Nil(t, err)
+	_, err = doctree.EnsureGenerated()
+	assert.Error(t, err)
+}
+
 // Test DocumentTree sets rootHash correctly and validated the generated Proof
 func TestDocumentTree_WithRootHash(t *testing.T) {
 	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
@@ -316,7 +349,7 @@ func TestTree_AddLeavesFromDocument_twice(t *testing.T) {
 	length := len(doctree.leaves)
 	assert.Nil(t, err)
 	err = doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample)
-	assert.EqualError(t, err, "duplicated leaf")
+	assert.EqualError(t, err, "duplicated leaf: readable name value0 is already used")
 	assert.Equal(t, length, len(doctree.leaves))
 	err = doctree.Generate()
 	assert.Nil(t, err)
@@ -402,6 +435,75 @@ func TestTree_GenerateNestedTreeCombinedStandardProof(t *testing.T) {
 	assert.True(t, valid)
 }
 
+func TestVerifyNestedProof(t *testing.T) {
+	for _, sorted := range []bool{false, true} {
+		doctreeA, err := NewDocumentTree(TreeOptions{EnableHashSorting: sorted, Hash: sha256Hash, Salts: NewSaltForTest})
+		assert.NoError(t, err)
+		assert.NoError(t, doctreeA.AddLeavesFromDocument(&documentspb.ExampleFilledRepeatedDocument))
+		assert.NoError(t, doctreeA.Generate())
+
+		doctreeB, err := NewDocumentTree(TreeOptions{EnableHashSorting: sorted, Hash: sha256Hash, Salts: NewSaltForTest})
+		assert.NoError(t, err)
+		assert.NoError(t, doctreeB.AddLeavesFromDocument(&documentspb.ExampleDocument{
+			ValueA:         "Example",
+			ValueNotHashed: doctreeA.RootHash(),
+		}))
+		assert.NoError(t, doctreeB.Generate())
+
+		fieldProofA, err := doctreeA.CreateProof("valueA")
+		assert.NoError(t, err)
+		fieldProofB, err := doctreeB.CreateProof("value_not_hashed")
+		assert.NoError(t, err)
+
+		valid, err := VerifyNestedProof(&fieldProofA, doctreeA.RootHash(), &fieldProofB, doctreeB.RootHash(), sha256Hash)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = VerifyNestedProof(&fieldProofA, doctreeB.RootHash(), &fieldProofB, doctreeB.RootHash(), sha256Hash)
+		assert.EqualError(t, err, "inner proof: Hash does not match")
+		assert.False(t, valid)
+
+		// the outer field has to hold the inner root
+		otherProofB, err := doctreeB.CreateProof("valueA")
+		assert.NoError(t, err)
+		valid, err = VerifyNestedProof(&fieldProofA, doctreeA.RootHash(), &otherProofB, doctreeB.RootHash(), sha256Hash)
+		assert.EqualError(t, err, "outer proof doesn't commit to the inner root")
+		assert.False(t, valid)
+
+		valid, err = VerifyNestedProof(&fieldProofA, doctreeA.RootHash(), &fieldProofB, doctreeA.RootHash(), sha256Hash)
+		assert.EqualError(t, err, "outer proof: Hash does not match")
+		assert.False(t, valid)
+	}
+}
+
+func TestValidateProofByBinaryPath(t *testing.T) {
+	exampleType := reflect.TypeOf(documentspb.ExampleDocument{})
+	for _, sorted := range []bool{false, true} {
+		doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: sorted, Hash: sha256Hash, Salts: NewSaltForTest})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleDocument{
+			ValueA:         "foo",
+			ValueB:         "bar",
+			ValueNotHashed: hashBytes(sha256Hash, []byte("baz")),
+		}))
+		assert.NoError(t, doctree.Generate())
+		proof, err := doctree.CreateProof("valueA")
+		assert.NoError(t, err)
+
+		valid, err := ValidateProofByBinaryPath(exampleType, []uint64{1}, proof.Value, proof.Salt, doctree.RootHash(), proof.Hashes, proof.SortedHashes, sha256Hash, sorted)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		// the path of valueB doesn't match the proof
+		valid, err = ValidateProofByBinaryPath(exampleType, []uint64{2}, proof.Value, proof.Salt, doctree.RootHash(), proof.Hashes, proof.SortedHashes, sha256Hash, sorted)
+		assert.EqualError(t, err, "Hash does not match")
+		assert.False(t, valid)
+	}
+
+	_, err := ValidateProofByBinaryPath(exampleType, []uint64{99}, nil, nil, nil, nil, nil, sha256Hash, false)
+	assert.EqualError(t, err, "no field 99 in documents.ExampleDocument")
+}
+
 func TestTree_GenerateNestedTreeCombinedSortedHashesProof(t *testing.T) {
 	doctreeA, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
 	assert.Nil(t, err)
@@ -513,6 +615,41 @@ func TestTree_GenerateProofHashed(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestTree_GenerateProofRepeatedHashed(t *testing.T) {
+	hashA := sha256.Sum256([]byte("A"))
+	hashB := sha256.Sum256([]byte("B"))
+	doc := &documentspb.RepeatedHashedFieldDocument{
+		Hashes: [][]byte{hashA[:], hashB[:]},
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(doc)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+	assert.Nil(t, err)
+
+	assert.Equal(t, []Property{
+		Empty.FieldProp("hashes", 1).LengthProp(DefaultReadablePropertyLengthSuffix),
+		Empty.FieldProp("hashes", 1).SliceElemProp(0),
+		Empty.FieldProp("hashes", 1).SliceElemProp(1),
+	}, doctree.PropertyOrder())
+	assert.False(t, doctree.leaves[0].Hashed)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 2}, doctree.leaves[0].Value)
+	assert.True(t, doctree.leaves[2].Hashed)
+	assert.Equal(t, hashB[:], doctree.leaves[2].Hash)
+
+	fieldProof, err := doctree.CreateProof("hashes[1]")
+	assert.Nil(t, err)
+	assert.Equal(t, hashB[:], fieldProof.Hash)
+	assert.Empty(t, fieldProof.Value)
+	valid, err := ValidateProofHashes(hashB[:], fieldProof.Hashes, doctree.rootHash, doctree.hash)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+	valid, err = doctree.ValidateProof(&fieldProof)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+}
+
 func TestTree_GenerateSortedProof(t *testing.T) {
 	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
 	assert.Nil(t, err)
@@ -533,6 +670,276 @@ func TestTree_GenerateSortedProof(t *testing.T) {
 
 }
 
+func TestSortedHashesValidator(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+	assert.Nil(t, err)
+
+	validator := NewSortedHashesValidator(sha256.New())
+	for i, leaf := range doctree.leaves {
+		hashes, err := doctree.pickHashesFromMerkleTreeAsList(uint64(i))
+		assert.Nil(t, err)
+		hash := append([]byte{}, leaf.Hash...)
+
+		expectedValid, expectedErr := ValidateProofSortedHashes(leaf.Hash, hashes, doctree.rootHash, sha256Hash)
+		valid, err := validator.Validate(leaf.Hash, hashes, doctree.rootHash)
+		assert.Equal(t, expectedValid, valid)
+		assert.Equal(t, expectedErr, err)
+		assert.True(t, valid)
+		assert.Equal(t, hash, leaf.Hash, "leaf hash must not be modified")
+
+		// invalid root
+		expectedValid, expectedErr = ValidateProofSortedHashes(leaf.Hash, hashes, leaf.Hash, sha256Hash)
+		valid, err = validator.Validate(leaf.Hash, hashes, leaf.Hash)
+		assert.Equal(t, expectedValid, valid)
+		assert.EqualError(t, err, expectedErr.Error())
+	}
+}
+
+// genericHash hides the type of the wrapped hash, so the sha256 fast path isn't taken
+type genericHash struct {
+	hash.Hash
+}
+
+func TestSum_SHA256FastPath(t *testing.T) {
+	input := []byte("leaf")
+	fast, err := sum(sha256.New(), input)
+	assert.NoError(t, err)
+	generic, err := sum(genericHash{sha256.New()}, input)
+	assert.NoError(t, err)
+	assert.Equal(t, fast, generic)
+	expected := sha256.Sum256(input)
+	assert.Equal(t, expected[:], fast)
+
+	// sha224 shares the implementation of sha256
+	fast, err = sum(sha256.New224(), input)
+	assert.NoError(t, err)
+	assert.Len(t, fast, sha256.Size224)
+
+	var roots [][]byte
+	for _, h := range []hash.Hash{sha256.New(), genericHash{sha256.New()}} {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: h, Salts: NewSaltForTest})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+		assert.NoError(t, doctree.Generate())
+		roots = append(roots, doctree.RootHash())
+	}
+	assert.Equal(t, roots[0], roots[1])
+}
+
+func benchmarkHashNode(b *testing.B, h hash.Hash) {
+	leaf := LeafNode{Property: NewProperty("valueA", 1), Value: []byte("foo"), Salt: make([]byte, 32)}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		leaf.Hash = nil
+		if err := leaf.HashNode(h, false); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHashNode_SHA256FastPath(b *testing.B) {
+	benchmarkHashNode(b, sha256.New())
+}
+
+func BenchmarkHashNode_Generic(b *testing.B) {
+	benchmarkHashNode(b, genericHash{sha256.New()})
+}
+
+func BenchmarkValidateProofSortedHashes(b *testing.B) {
+	doctree, _ := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256.New(), Salts: NewSaltForTest})
+	_ = doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample)
+	_ = doctree.Generate()
+	hashes, _ := doctree.pickHashesFromMerkleTreeAsList(0)
+	fieldHash := doctree.leaves[0].Hash
+
+	b.Run("ValidateProofSortedHashes", func(b *testing.B) {
+		h := sha256.New()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ValidateProofSortedHashes(fieldHash, hashes, doctree.rootHash, h)
+		}
+	})
+	b.Run("SortedHashesValidator", func(b *testing.B) {
+		validator := NewSortedHashesValidator(sha256.New())
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = validator.Validate(fieldHash, hashes, doctree.rootHash)
+		}
+	})
+}
+
+func TestTree_NestedMapProofs(t *testing.T) {
+	message := &documentspb.NestedMap{
+		Value: map[int32]*documentspb.SimpleMap{
+			42: {
+				Value: map[int32]string{
+					-42: "value",
+					7:   "other",
+				},
+			},
+			-1: {
+				Value: map[int32]string{
+					-2147483648: "min",
+				},
+			},
+		},
+	}
+
+	for _, compact := range []bool{false, true} {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, CompactProperties: compact})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(message))
+		assert.NoError(t, doctree.Generate())
+
+		for _, test := range []struct {
+			prop    string
+			compact []byte
+			value   string
+		}{
+			{"value[42].value[-42]", []byte{0, 0, 0, 1, 0, 0, 0, 42, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xd6}, "value"},
+			{"value[42].value[7]", []byte{0, 0, 0, 1, 0, 0, 0, 42, 0, 0, 0, 1, 0, 0, 0, 7}, "other"},
+			{"value[-1].value[-2147483648]", []byte{0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 1, 0x80, 0, 0, 0}, "min"},
+		} {
+			proof, err := doctree.CreateProof(test.prop)
+			assert.NoError(t, err)
+			assert.Equal(t, test.value, string(proof.Value))
+			if compact {
+				assert.Equal(t, test.compact, proof.GetCompactName())
+			} else {
+				assert.Equal(t, test.prop, proof.GetReadableName())
+			}
+			valid, err := doctree.ValidateProof(&proof)
+			assert.NoError(t, err)
+			assert.True(t, valid)
+
+			proof, err = doctree.CreateProofWithCompactProp(test.compact)
+			assert.NoError(t, err)
+			valid, err = doctree.ValidateProof(&proof)
+			assert.NoError(t, err)
+			assert.True(t, valid)
+		}
+	}
+}
+
+func TestTree_VerifyCachedLeafHashes(t *testing.T) {
+	leaves, err := FlattenMessage(&documentspb.LongDocumentExample, NewSaltForTest, DefaultReadablePropertyLengthSuffix, sha256Hash, false, Empty, false)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, leaves[0].Hash)
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, VerifyCachedLeafHashes: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeaves(leaves))
+	assert.NoError(t, doctree.Generate())
+
+	// a value that doesn't match the cached hash
+	mismatched := append([]LeafNode(nil), leaves...)
+	mismatched[1].Value = []byte("tampered")
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, VerifyCachedLeafHashes: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeaves(mismatched))
+	assert.EqualError(t, doctree.Generate(), fmt.Sprintf("cached hash %x of leaf %s doesn't match its value", leaves[1].Hash, leaves[1].Property.ReadableName()))
+
+	// without the option the cached hash is used
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeaves(mismatched))
+	assert.NoError(t, doctree.Generate())
+}
+
+func TestTree_EmbeddedMessage(t *testing.T) {
+	doc := &documentspb.EmbeddedDocument{ExampleDocument: documentspb.ExampleDocument{
+		ValueA:         "foo",
+		ValueNotHashed: hashBytes(sha256Hash, []byte("bar")),
+		Name:           &documentspb.Name{First: "john"},
+	}}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+
+	// the embedded fields are flattened under the property of the document
+	expected, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, expected.AddLeavesFromDocument(&doc.ExampleDocument))
+	assert.NoError(t, expected.Generate())
+	assert.Equal(t, expected.PropertyOrder(), doctree.PropertyOrder())
+	assert.Equal(t, expected.RootHash(), doctree.RootHash())
+
+	for _, prop := range []string{"valueA", "name"} {
+		proof, err := doctree.CreateProof(prop)
+		assert.NoError(t, err)
+		valid, err := doctree.ValidateProof(&proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	// generated salts are written to the salts field of the embedded message
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NotEmpty(t, doc.Salts)
+}
+
+func TestTree_SortByCompact(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, SortByCompact: true})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+	assert.Nil(t, err)
+	assert.Equal(t, Empty.FieldProp("value1", 1), doctree.leaves[0].Property)
+	assert.Equal(t, Empty.FieldProp("value0", 16), doctree.leaves[14].Property)
+
+	// leaves hashed with readable names, ordered by compact names
+	leaves, err := FlattenMessage(&documentspb.LongDocumentExample, NewSaltForTest, DefaultReadablePropertyLengthSuffix, sha256Hash, false, Empty, false)
+	assert.Nil(t, err)
+	sort.Sort(sortByCompactName{leaves})
+	compactOrdered, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.Nil(t, err)
+	err = compactOrdered.AddLeaves(leaves)
+	assert.Nil(t, err)
+	err = compactOrdered.Generate()
+	assert.Nil(t, err)
+	assert.Equal(t, compactOrdered.RootHash(), doctree.RootHash())
+
+	readableOrdered, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = readableOrdered.AddLeavesFromDocument(&documentspb.LongDocumentExample)
+	assert.Nil(t, err)
+	err = readableOrdered.Generate()
+	assert.Nil(t, err)
+	assert.NotEqual(t, readableOrdered.RootHash(), doctree.RootHash())
+
+	proof, err := doctree.CreateProof("value0")
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("value0"), proof.Property)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.Nil(t, err)
+	assert.True(t, valid)
+
+	// the compact order is the order of the field number paths
+	nested, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, SortByCompact: true})
+	assert.Nil(t, err)
+	assert.Nil(t, nested.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+	var previous []uint64
+	for _, leaf := range nested.leaves {
+		fieldNums, err := leaf.Property.FieldNums()
+		assert.Nil(t, err)
+		for i := 0; i < len(previous) && i < len(fieldNums.Components); i++ {
+			if previous[i] != fieldNums.Components[i] {
+				assert.True(t, previous[i] < fieldNums.Components[i], "%v before %v", previous, fieldNums.Components)
+				break
+			}
+		}
+		previous = fieldNums.Components
+	}
+}
+
 func TestTree_GenerateWithRepeatedFields(t *testing.T) {
 	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
 	assert.Nil(t, err)
@@ -589,369 +996,1981 @@ func TestTree_GenerateWithNestedAndRepeatedFields(t *testing.T) {
 	assert.True(t, valid)
 }
 
-func TestCreateProof_standard(t *testing.T) {
-	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	doc := documentspb.FilledExampleDocument
-	doc.ValueNotHashed = sha256Hash.Sum([]byte("some hash"))
-	doc.ValueBytes1 = []byte("ValueBytes1")
-	err = doctree.AddLeavesFromDocument(&doc)
-	assert.Nil(t, err)
+func TestTree_ExcludeFields(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, ExcludeFields: []string{"valueD.valueB"}})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+	assert.NoError(t, doctree.Generate())
+	assert.Equal(t, []Property{
+		Empty.FieldProp("valueA", 1),
+		Empty.FieldProp("valueB", 2),
+		Empty.FieldProp("valueC", 3).LengthProp(DefaultReadablePropertyLengthSuffix),
+		Empty.FieldProp("valueC", 3).SliceElemProp(0).FieldProp("valueA", 1),
+		Empty.FieldProp("valueC", 3).SliceElemProp(1).FieldProp("valueA", 1),
+		Empty.FieldProp("valueD", 4).FieldProp("valueA", 1).FieldProp("valueA", 1),
+	}, doctree.PropertyOrder())
 
-	proof, err := doctree.CreateProof("valueA")
-	assert.EqualError(t, err, "Can't create proof before generating merkle root")
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, ExcludeFields: []string{"valueC[1].valueA", "valueD.valueA"}})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+	assert.Equal(t, []Property{
+		Empty.FieldProp("valueA", 1),
+		Empty.FieldProp("valueB", 2),
+		Empty.FieldProp("valueC", 3).LengthProp(DefaultReadablePropertyLengthSuffix),
+		Empty.FieldProp("valueC", 3).SliceElemProp(0).FieldProp("valueA", 1),
+		Empty.FieldProp("valueD", 4).FieldProp("valueB", 2),
+	}, doctree.PropertyOrder())
+}
 
-	err = doctree.Generate()
-	assert.Nil(t, err)
+func TestTree_UseJSONNames(t *testing.T) {
+	doc := &documentspb.ExampleDocument{
+		ValueBytes1:    []byte("foo"),
+		ValueNotHashed: hashBytes(sha256Hash, []byte("bar")),
+		Name:           &documentspb.Name{First: "john"},
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, UseJSONNames: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
 
-	_, err = doctree.CreateProof("InexistentField")
-	assert.EqualError(t, err, "No such field: InexistentField in obj")
+	proof, err := doctree.CreateProof("valueBytes1")
+	assert.NoError(t, err)
+	assert.Equal(t, "valueBytes1", proof.GetReadableName())
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	_, err = doctree.CreateProof("value_bytes1")
+	assert.EqualError(t, err, "No such field: value_bytes1 in obj")
+	_, err = doctree.CreateProof("valueNotHashed")
+	assert.NoError(t, err)
 
-	proof, err = doctree.CreateProof("valueA")
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("valueA"), proof.Property)
-	assert.Equal(t, []byte(documentspb.FilledExampleDocument.ValueA), proof.Value)
-	assert.Equal(t, testSalt, proof.Salt)
+	// compact names don't change
+	protoNames, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, protoNames.AddLeavesFromDocument(doc))
+	_, leaf := protoNames.GetLeafByProperty("value_bytes1")
+	_, jsonLeaf := doctree.GetLeafByProperty("valueBytes1")
+	assert.Equal(t, leaf.Property.CompactName(), jsonLeaf.Property.CompactName())
+}
 
-	proofB, err := doctree.CreateProof("value_bytes1")
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("value_bytes1"), proofB.Property)
-	assert.Equal(t, doc.ValueBytes1, proofB.Value)
-	assert.Equal(t, testSalt, proofB.Salt)
+func TestTree_NormalizeNameCase(t *testing.T) {
+	doc := &documentspb.ExampleDocument{
+		ValueA:          "foo",
+		ValueCamelCased: []byte("bar"),
+		ValueNotHashed:  hashBytes(sha256Hash, []byte("baz")),
+	}
+	readableNames := func(doctree DocumentTree) []string {
+		var names []string
+		for _, prop := range doctree.PropertyOrder() {
+			names = append(names, prop.ReadableName())
+		}
+		return names
+	}
 
-	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
-	rootHash := []byte{0x8d, 0xb4, 0x0, 0xfe, 0x35, 0x17, 0xf0, 0x23, 0xa3, 0xe7, 0x29, 0xa, 0x8b, 0x92, 0x5e, 0x1b, 0xde, 0xee, 0x47, 0xe8, 0x81, 0x93, 0x3e, 0xfe, 0x7f, 0x27, 0x6a, 0x90, 0x6f, 0x6d, 0x84, 0x28}
-	assert.Equal(t, rootHash, doctree.rootHash)
-	valid, err := ValidateProofHashes(fieldHash, proof.Hashes, rootHash, doctree.hash)
-	assert.True(t, valid)
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+	assert.Equal(t, "ValueCamelCased", readableNames(doctree)[0])
 
-	valid, err = doctree.ValidateProof(&proof)
+	normalized, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, NormalizeNameCase: strings.ToLower})
+	assert.NoError(t, err)
+	assert.NoError(t, normalized.AddLeavesFromDocument(doc))
+	assert.NoError(t, normalized.Generate())
+	names := readableNames(normalized)
+	assert.True(t, sort.StringsAreSorted(names))
+	assert.Equal(t, []string{"enum_type", "paddinga", "paddingb", "value1", "value2", "value_bytes1",
+		"value_not_hashed", "value_not_ignored", "valuea", "valueb", "valuebool", "valuecamelcased"}, names)
+	assert.NotEqual(t, doctree.RootHash(), normalized.RootHash())
+
+	proof, err := normalized.CreateProof("valuecamelcased")
+	assert.NoError(t, err)
+	valid, err := normalized.ValidateProof(&proof)
+	assert.NoError(t, err)
 	assert.True(t, valid)
-	assert.Nil(t, err)
+	_, err = normalized.CreateProof("ValueCamelCased")
+	assert.EqualError(t, err, "No such field: ValueCamelCased in obj")
 
-	valid, err = doctree.ValidateProof(&proofB)
+	// compact names don't change
+	_, leaf := doctree.GetLeafByProperty("ValueCamelCased")
+	_, normalizedLeaf := normalized.GetLeafByProperty("valuecamelcased")
+	assert.Equal(t, leaf.Property.CompactName(), normalizedLeaf.Property.CompactName())
+}
+
+func TestTree_HashSalt(t *testing.T) {
+	shortSalts := func(compact []byte) ([]byte, error) {
+		return []byte("short salt"), nil
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: shortSalts, HashSalt: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("short salt"), proof.Salt)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
 	assert.True(t, valid)
-	assert.Nil(t, err)
 
-	falseProof, err := doctree.CreateProof("valueA")
-	falseProof.Value = []byte{}
-	valid, err = doctree.ValidateProof(&falseProof)
-	assert.False(t, valid)
-	assert.EqualError(t, err, "Hash does not match")
+	_, leaf := doctree.GetLeafByProperty("value1")
+	input := append([]byte("value1"), proof.Value...)
+	input = append(input, hashBytes(sha256Hash, []byte("short salt"))...)
+	assert.Equal(t, hashBytes(sha256Hash, input), leaf.Hash)
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: shortSalts})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample)
+	assert.EqualError(t, err, "value0: Salt has incorrect length: 10 instead of 32")
 }
 
-func TestCreateProof_compact(t *testing.T) {
-	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	doc := documentspb.FilledExampleDocument
-	doc.ValueNotHashed = sha256Hash.Sum([]byte("some hash"))
-	doc.ValueBytes1 = []byte("ValueBytes1")
-	err = doctree.AddLeavesFromDocument(&doc)
-	assert.Nil(t, err)
+func TestTree_HashValuesOver(t *testing.T) {
+	file := bytes.Repeat([]byte{0xab}, 1024)
+	doc := &documentspb.ExampleDocument{ValueA: "foo", ValueBytes1: file}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, HashValuesOver: 64})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
 
-	proof, err := doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("valueA"))
-	assert.EqualError(t, err, "Can't create proof before generating merkle root")
+	_, leaf := doctree.GetLeafByProperty("value_bytes1")
+	assert.True(t, leaf.Hashed)
+	assert.Empty(t, leaf.Value)
+	proof, err := doctree.CreateProof("value_bytes1")
+	assert.NoError(t, err)
+	assert.Empty(t, proof.Value)
+	assert.Empty(t, proof.Salt)
+	assert.Equal(t, hashBytes(sha256Hash, file), proof.Hash)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
 
-	err = doctree.Generate()
-	assert.Nil(t, err)
+	_, leaf = doctree.GetLeafByProperty("valueA")
+	assert.False(t, leaf.Hashed)
+	assert.Equal(t, []byte("foo"), leaf.Value)
+}
 
-	_, err = doctree.CreateProofWithCompactProp([]byte{1, 1, 1, 1})
-	assert.EqualError(t, err, "No such field: 01010101 in obj")
+func TestKeccakHasher(t *testing.T) {
+	h := NewKeccakHasher()
+	assert.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", hex.EncodeToString(hashBytes(h, []byte{})))
 
-	proof, err = doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("valueA"))
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("valueA"), proof.Property)
-	assert.Equal(t, []byte(documentspb.FilledExampleDocument.ValueA), proof.Value)
-	assert.Equal(t, testSalt, proof.Salt)
+	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: h, CompactProperties: true, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	assert.Len(t, doctree.RootHash(), 32)
 
-	proofB, err := doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("value_bytes1"))
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("value_bytes1"), proofB.Property)
-	assert.Equal(t, doc.ValueBytes1, proofB.Value)
-	assert.Equal(t, testSalt, proofB.Salt)
+	for _, leaf := range doctree.GetLeaves() {
+		proof, err := doctree.CreateProofWithCompactProp(leaf.Property.CompactName())
+		assert.NoError(t, err)
+		valid, err := doctree.ValidateProof(&proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
 
-	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
-	rootHash := []byte{0x8d, 0xb4, 0x0, 0xfe, 0x35, 0x17, 0xf0, 0x23, 0xa3, 0xe7, 0x29, 0xa, 0x8b, 0x92, 0x5e, 0x1b, 0xde, 0xee, 0x47, 0xe8, 0x81, 0x93, 0x3e, 0xfe, 0x7f, 0x27, 0x6a, 0x90, 0x6f, 0x6d, 0x84, 0x28}
-	assert.Equal(t, rootHash, doctree.rootHash)
-	valid, err := ValidateProofHashes(fieldHash, proof.Hashes, rootHash, doctree.hash)
-	assert.True(t, valid)
+		fieldHash, err := CalculateHashForProofField(&proof, NewKeccakHasher())
+		assert.NoError(t, err)
+		valid, err = ValidateProofSortedHashes(fieldHash, proof.SortedHashes, doctree.RootHash(), NewKeccakHasher())
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+}
 
-	valid, err = doctree.ValidateProof(&proof)
-	assert.True(t, valid)
-	assert.Nil(t, err)
+func TestTree_EVMEncoding(t *testing.T) {
+	doc := &documentspb.Integers{ValueA: -1, ValueB: -1, ValueD: 2, ValueE: 3}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: NewKeccakHasher(), EnableHashSorting: true, EVMEncoding: true, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
 
-	valid, err = doctree.ValidateProof(&proofB)
+	proof, err := doctree.CreateProof("valueB")
+	assert.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0xff}, 32), proof.Value)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
 	assert.True(t, valid)
-	assert.Nil(t, err)
 
-	falseProof, err := doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("valueA"))
-	falseProof.Value = []byte{}
-	valid, err = doctree.ValidateProof(&falseProof)
-	assert.False(t, valid)
-	assert.EqualError(t, err, "Hash does not match")
+	proof, err = doctree.CreateProof("valueA")
+	assert.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0xff}, 32), proof.Value)
 
-	// nested
-	docNested := documentspb.ExampleFilledNestedRepeatedDocument
-	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&docNested)
-	assert.Nil(t, err)
-	err = doctree.Generate()
-	assert.Nil(t, err)
-	s := doctree.GetCompactPropByPropertyName("valueD.valueB")
-	proof, err = doctree.CreateProofWithCompactProp(s)
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("valueD.valueB"), proof.Property)
-	assert.Equal(t, []byte(documentspb.ExampleFilledNestedRepeatedDocument.ValueD.ValueB), proof.Value)
-	assert.Equal(t, testSalt, proof.Salt)
-}
+	proof, err = doctree.CreateProof("valueD")
+	assert.NoError(t, err)
+	assert.Equal(t, append(make([]byte, 31), 2), proof.Value)
 
-func TestCreateProof_standard_compactProperties(t *testing.T) {
-	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, CompactProperties: true, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	doc := documentspb.FilledExampleDocument
-	doc.ValueBytes1 = []byte("ValueBytes1")
-	err = doctree.AddLeavesFromDocument(&doc)
-	assert.Nil(t, err)
+	// the root matches a tree built from leaves encoded the same way
+	var leaves []LeafNode
+	for _, leaf := range doctree.GetLeaves() {
+		leaves = append(leaves, LeafNode{Property: leaf.Property, Value: leaf.Value, Salt: leaf.Salt})
+	}
+	manual, err := NewDocumentTree(TreeOptions{Hash: NewKeccakHasher(), EnableHashSorting: true})
+	assert.NoError(t, err)
+	assert.NoError(t, manual.AddLeaves(leaves))
+	assert.NoError(t, manual.Generate())
+	assert.Equal(t, doctree.RootHash(), manual.RootHash())
+}
 
-	proof, err := doctree.CreateProof("valueA")
-	assert.EqualError(t, err, "Can't create proof before generating merkle root")
+func TestTree_LengthEncoder(t *testing.T) {
+	word := func(length int) ([]byte, error) {
+		return toEVMWord(length)
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, LengthEncoder: word})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.SimpleRepeatedDocument{ValueC: []string{"a", "b", "c"}}))
+	assert.NoError(t, doctree.Generate())
 
-	err = doctree.Generate()
-	assert.Nil(t, err)
+	proof, err := doctree.CreateProof("valueC.length")
+	assert.NoError(t, err)
+	assert.Equal(t, append(make([]byte, 31), 3), proof.Value)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
 
-	_, err = doctree.CreateProof("InexistentField")
-	assert.EqualError(t, err, "No such field: InexistentField in obj")
+	// a proof with the default encoding doesn't match
+	proof.Value, err = toBytesArray(3)
+	assert.NoError(t, err)
+	_, err = doctree.ValidateProof(&proof)
+	assert.EqualError(t, err, "Hash does not match")
 
-	proof, err = doctree.CreateProof("valueA")
-	assert.Nil(t, err)
-	assert.Equal(t, CompactName(0, 0, 0, 1), proof.Property)
-	assert.Equal(t, []byte(documentspb.FilledExampleDocument.ValueA), proof.Value)
-	assert.Equal(t, testSalt, proof.Salt)
+	failing := func(length int) ([]byte, error) {
+		return nil, errors.New("can't encode length")
+	}
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, LengthEncoder: failing})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.SimpleRepeatedDocument{ValueC: []string{"a"}})
+	assert.EqualError(t, err, "error handling field ValueC: can't encode length")
+}
 
-	proofB, err := doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("value_bytes1"))
-	assert.Nil(t, err)
-	assert.Equal(t, CompactName(0, 0, 0, 5), proofB.Property)
-	assert.Equal(t, doc.ValueBytes1, proofB.Value)
-	assert.Equal(t, testSalt, proofB.Salt)
+func TestTree_SchemaCommitment(t *testing.T) {
+	commitment := func(doc proto.Message) []byte {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+		return doctree.SchemaCommitment()
+	}
 
-	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
-	rootHash := []byte{0xfa, 0x54, 0x43, 0x87, 0xc7, 0x3c, 0x64, 0xc9, 0x77, 0x6a, 0x9a, 0x9a, 0x79, 0xb2, 0xdf, 0xa, 0x71, 0x71, 0xd0, 0xfc, 0x14, 0xf0, 0xbd, 0x45, 0x48, 0x50, 0xb4, 0x36, 0xf2, 0xac, 0xe3, 0x46}
-	assert.Equal(t, rootHash, doctree.rootHash)
-	valid, err := ValidateProofHashes(fieldHash, proof.Hashes, rootHash, doctree.hash)
-	assert.True(t, valid)
+	first := commitment(&documentspb.ExampleDocument{ValueA: "foo", Value1: 1})
+	second := commitment(&documentspb.ExampleDocument{ValueA: "bar", ValueB: "baz", Value2: 42, ValueBool: true})
+	assert.Len(t, first, 32)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, commitment(&documentspb.ContainSalts{ValueA: "foo"}))
+}
 
-	valid, err = doctree.ValidateProof(&proof)
-	assert.True(t, valid)
-	assert.Nil(t, err)
+func TestTree_ValidateProofWithValue(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleDocument{
+		ValueA:         "foo",
+		ValueNotHashed: hashBytes(sha256Hash, []byte("bar")),
+	}))
+	assert.NoError(t, doctree.Generate())
 
-	valid, err = doctree.ValidateProof(&proofB)
+	proof, err := doctree.CreateProof("valueA")
+	assert.NoError(t, err)
+	valid, err := doctree.ValidateProofWithValue(&proof, []byte("foo"))
+	assert.NoError(t, err)
 	assert.True(t, valid)
-	assert.Nil(t, err)
 
-	falseProof, err := doctree.CreateProof("valueA")
-	falseProof.Value = []byte{}
-	valid, err = doctree.ValidateProof(&falseProof)
+	// the proof is valid but doesn't prove the expected value
+	valid, err = doctree.ValidateProofWithValue(&proof, []byte("other"))
+	assert.Equal(t, ErrValueMismatch, err)
 	assert.False(t, valid)
+
+	// a tampered value fails the proof itself
+	proof.Value = []byte("other")
+	valid, err = doctree.ValidateProofWithValue(&proof, []byte("other"))
 	assert.EqualError(t, err, "Hash does not match")
+	assert.False(t, valid)
 }
 
-func TestCreateOneofProof(t *testing.T) {
+func TestTree_AddLeavesFromJSON(t *testing.T) {
+	hashed := hashBytes(sha256Hash, []byte("bar"))
+	data := []byte(fmt.Sprintf(`{"valueA": "foo", "valueNotHashed": "0x%x"}`, hashed))
 	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&documentspb.ExampleOneofSampleDocument)
-	assert.Nil(t, err)
-	err = doctree.Generate()
+	assert.NoError(t, err)
+	doc := new(documentspb.ExampleDocument)
+	assert.NoError(t, doctree.AddLeavesFromJSON(data, doc))
+	assert.Equal(t, hashed, doc.ValueNotHashed)
+	assert.NoError(t, doctree.Generate())
 
-	_, err = doctree.CreateProof("valueC")
-	assert.EqualError(t, err, "No such field: valueC in obj")
+	_, leaf := doctree.GetLeafByProperty("value_not_hashed")
+	assert.NotNil(t, leaf)
+	assert.True(t, leaf.Hashed)
+	assert.Equal(t, hashed, leaf.Hash)
 
-	proof, err := doctree.CreateProof("valueB")
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("valueB"), proof.Property)
-	ev, err := toBytesArray(documentspb.ExampleOneofSampleDocument.OneofBlock.(*documentspb.OneofSample_ValueB).ValueB)
+	// the base64 encoding of jsonpb results in the same tree
+	expected, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
 	assert.NoError(t, err)
-	assert.Equal(t, ev, proof.Value)
-	assert.Equal(t, testSalt, proof.Salt)
+	data = []byte(fmt.Sprintf(`{"valueA": "foo", "valueNotHashed": "%s"}`, base64.StdEncoding.EncodeToString(hashed)))
+	assert.NoError(t, expected.AddLeavesFromJSON(data, new(documentspb.ExampleDocument)))
+	assert.NoError(t, expected.Generate())
+	assert.Equal(t, expected.RootHash(), doctree.RootHash())
 
-	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
-	rootHash := []byte{0x8, 0x98, 0x8d, 0x75, 0x33, 0xac, 0xc, 0xad, 0x96, 0x2c, 0x9, 0x38, 0x37, 0x2e, 0x44, 0x32, 0x3c, 0x1c, 0xa1, 0xe5, 0xf3, 0x35, 0xdb, 0x21, 0x9f, 0x97, 0x8e, 0x6b, 0x17, 0x4e, 0xa5, 0xa2}
-	assert.Equal(t, rootHash, doctree.rootHash)
-	valid, err := ValidateProofHashes(fieldHash, proof.Hashes, rootHash, doctree.hash)
-	assert.True(t, valid)
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromJSON([]byte(`{"valueNotHashed": "0xzz"}`), new(documentspb.ExampleDocument))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "malformed hex value 0xzz")
+}
 
-	valid, err = doctree.ValidateProof(&proof)
+func TestVerifyProofSalt(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+
+	expected, err := NewSaltForTest(nil)
+	assert.NoError(t, err)
+	assert.True(t, VerifyProofSalt(&proof, expected))
+
+	// the proof is valid, but its salt is not the committed one
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
 	assert.True(t, valid)
-	assert.Nil(t, err)
+	other := sha256.Sum256([]byte("other salt"))
+	assert.False(t, VerifyProofSalt(&proof, other[:]))
+	assert.False(t, VerifyProofSalt(&proof, expected[:16]))
+	assert.False(t, VerifyProofSalt(&proof, nil))
+}
 
-	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&documentspb.OneofSample{
-		OneofBlock: &documentspb.OneofSample_ValueC{"bor"},
+func TestDecodeJSONProofsStream(t *testing.T) {
+	values := make([]string, 1000)
+	for i := range values {
+		values[i] = strconv.Itoa(i)
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.SimpleRepeatedDocument{ValueA: "foo", ValueC: values}))
+	assert.NoError(t, doctree.Generate())
+
+	var fieldProofs []json.RawMessage
+	marshaler := jsonpb.Marshaler{}
+	for _, prop := range doctree.PropertyOrder() {
+		proof, err := doctree.CreateProof(prop.ReadableName())
+		assert.NoError(t, err)
+		encoded, err := marshaler.MarshalToString(&proof)
+		assert.NoError(t, err)
+		fieldProofs = append(fieldProofs, json.RawMessage(encoded))
+	}
+	bundle, err := json.Marshal(map[string]interface{}{
+		"header":        "ignored",
+		"document_root": doctree.RootHash(),
+		"field_proofs":  fieldProofs,
 	})
-	assert.Nil(t, err)
-	err = doctree.Generate()
+	assert.NoError(t, err)
 
-	_, err = doctree.CreateProof("valueB")
-	assert.EqualError(t, err, "No such field: valueB in obj")
-	_, err = doctree.CreateProof("valueC")
-	assert.Nil(t, err)
+	count := 0
+	root, err := DecodeJSONProofsStream(bytes.NewReader(bundle), func(proof *proofspb.Proof) error {
+		count++
+		valid, err := doctree.ValidateProof(proof)
+		assert.True(t, valid)
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, doctree.RootHash(), root)
+	assert.Equal(t, len(fieldProofs), count)
 
-	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&documentspb.OneofSample{
-		OneofBlock: &documentspb.OneofSample_ValueD{&documentspb.SimpleItem{ValueA: "testA"}},
+	count = 0
+	_, err = DecodeJSONProofsStream(bytes.NewReader(bundle), func(proof *proofspb.Proof) error {
+		count++
+		return errors.New("stop")
 	})
-	assert.Nil(t, err)
-	err = doctree.Generate()
+	assert.EqualError(t, err, "stop")
+	assert.Equal(t, 1, count)
 
-	_, err = doctree.CreateProof("valueC")
-	assert.EqualError(t, err, "No such field: valueC in obj")
-	_, err = doctree.CreateProof("valueD.valueA")
-	assert.Nil(t, err)
+	_, err = DecodeJSONProofsStream(strings.NewReader(`[]`), func(proof *proofspb.Proof) error { return nil })
+	assert.EqualError(t, err, "expected { but got [")
+}
 
-	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&documentspb.OneofSample{})
-	assert.Nil(t, err)
-	err = doctree.Generate()
+func TestValidateProofAnyRoot(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
 
-	_, err = doctree.CreateProof("valueB")
-	assert.EqualError(t, err, "No such field: valueB in obj")
-	_, err = doctree.CreateProof("valueD.valueA")
-	assert.EqualError(t, err, "No such field: valueD.valueA in obj")
-	_, err = doctree.CreateProof("valueC")
-	assert.EqualError(t, err, "No such field: valueC in obj")
+	otherRoot := sha256.Sum256([]byte("other"))
+	roots := [][]byte{otherRoot[:], doctree.RootHash(), otherRoot[:]}
+	index, valid, err := ValidateProofAnyRoot(&proof, roots, sha256Hash, false)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, 1, index)
 
-}
+	index, valid, err = ValidateProofAnyRoot(&proof, roots[:1], sha256Hash, false)
+	assert.EqualError(t, err, "Hash does not match")
+	assert.False(t, valid)
+	assert.Equal(t, -1, index)
 
-func TestCreateProof_sorted(t *testing.T) {
-	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&documentspb.FilledExampleDocument)
-	assert.Nil(t, err)
-	err = doctree.Generate()
-	assert.Nil(t, err)
+	sortedTree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, EnableHashSorting: true, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, sortedTree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, sortedTree.Generate())
+	proof, err = sortedTree.CreateProof("value1")
+	assert.NoError(t, err)
+	roots = [][]byte{doctree.RootHash(), sortedTree.RootHash(), otherRoot[:]}
+	index, valid, err = ValidateProofAnyRoot(&proof, roots, sha256Hash, true)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, 1, index)
+}
 
-	_, err = doctree.CreateProof("InexistentField")
-	assert.EqualError(t, err, "No such field: InexistentField in obj")
+func TestValidateAnnotatedProof(t *testing.T) {
+	blake2b256, err := NewHashByName("blake2b-256")
+	assert.NoError(t, err)
+	doctree, err := NewDocumentTree(TreeOptions{Hash: blake2b256, EnableHashSorting: true, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
 
-	proof, err := doctree.CreateProof("valueA")
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("valueA"), proof.Property)
-	assert.Equal(t, []byte(documentspb.FilledExampleDocument.ValueA), proof.Value)
-	assert.Equal(t, testSalt, proof.Salt)
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	bundle, err := protojson.Marshal(&proofspb.AnnotatedProof{HashAlgo: "blake2b-256", Proof: &proof})
+	assert.NoError(t, err)
 
-	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
-	rootHash := []byte{0x54, 0x96, 0x85, 0x35, 0x65, 0x12, 0xb8, 0x63, 0x30, 0x51, 0xb5, 0x1f, 0x79, 0x99, 0x5a, 0x9a, 0x34, 0xc9, 0x34, 0x69, 0xa2, 0xb4, 0xd9, 0xca, 0x7a, 0x4c, 0x1f, 0x8e, 0xeb, 0x73, 0x6f, 0x74}
-	assert.Equal(t, rootHash, doctree.rootHash)
-	valid, err := ValidateProofSortedHashes(fieldHash, proof.SortedHashes, rootHash, doctree.hash)
+	annotated := new(proofspb.AnnotatedProof)
+	assert.NoError(t, protojson.Unmarshal(bundle, annotated))
+	assert.Equal(t, "blake2b-256", annotated.HashAlgo)
+	valid, err := ValidateAnnotatedProof(annotated, doctree.RootHash(), true)
+	assert.NoError(t, err)
 	assert.True(t, valid)
-
-	valid, err = doctree.ValidateProof(&proof)
+	valid, err = ValidateTypedAnnotatedProof(int64(2), "int64", annotated, doctree.RootHash(), true)
+	assert.NoError(t, err)
 	assert.True(t, valid)
-	assert.Nil(t, err)
 
-	falseProof, err := doctree.CreateProof("valueA")
-	falseProof.Value = []byte{}
-	valid, err = doctree.ValidateProof(&falseProof)
-	assert.False(t, valid)
+	annotated.HashAlgo = "sha256"
+	valid, err = ValidateAnnotatedProof(annotated, doctree.RootHash(), true)
 	assert.EqualError(t, err, "Hash does not match")
-}
+	assert.False(t, valid)
 
-func TestCreateRepeatedSortedProof(t *testing.T) {
-	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&documentspb.ExampleFilledRepeatedDocument)
-	assert.Nil(t, err)
-	err = doctree.Generate()
+	annotated.HashAlgo = "sha1024"
+	_, err = ValidateAnnotatedProof(annotated, doctree.RootHash(), true)
+	assert.EqualError(t, err, `unknown hash algorithm "sha1024"`)
 
-	_, err = doctree.CreateProof("InexistentField")
+	RegisterHashAlgo("blake2b-512-keyed", func() hash.Hash {
+		h, _ := blake2b.New512([]byte{1, 2, 3, 4})
+		return h
+	})
+	h, err := NewHashByName("blake2b-512-keyed")
+	assert.NoError(t, err)
+	assert.Equal(t, 64, h.Size())
+}
+
+func TestTree_MapOfLists(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ListMapDocument{
+		Lists: map[string]*documentspb.StringList{
+			"a": {Values: []string{"x", "y"}},
+			"b": {Values: []string{"z"}},
+		},
+	}))
+	assert.NoError(t, doctree.Generate())
+
+	for _, prop := range []string{"lists.length", "lists[a].values.length", "lists[a].values[1]", "lists[b].values[0]"} {
+		proof, err := doctree.CreateProof(prop)
+		assert.NoError(t, err)
+		valid, err := doctree.ValidateProof(&proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+}
+
+func TestTree_LeaksPosition(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, EnableHashSorting: true, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.False(t, doctree.LeaksPosition())
+	assert.Equal(t, []string{"proofs reveal the approximate number of leaves as the tree depth is not fixed"}, doctree.Warnings())
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.True(t, doctree.LeaksPosition())
+	assert.Contains(t, doctree.Warnings(), "proofs reveal the position of their leaf as hash sorting is disabled")
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, TreeDepth: 3, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.NoSaltDocument{ValueNoSalt: "foo", ValueSalt: "bar"}))
+	assert.True(t, doctree.LeaksPosition())
+	assert.Equal(t, []string{
+		"proofs reveal the position of their leaf as hash sorting is disabled",
+		"1 leaves are not salted and can be brute forced",
+	}, doctree.Warnings())
+}
+
+func TestTree_HashSizeMismatch(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, LeafHash: blake2bHash, TreeDepth: 3, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.Contains(t, doctree.Warnings(), "leaf hashes have 64 bytes but node hashes have 32 bytes")
+
+	_, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, LeafHash: blake2bHash, EVMEncoding: true, Salts: NewSaltForTest})
+	assert.EqualError(t, err, "EVMEncoding requires 32 byte hashes, got 32 byte Hash and 64 byte LeafHash")
+
+	_, err = NewDocumentTree(TreeOptions{Hash: NewKeccakHasher(), EVMEncoding: true, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+}
+
+func TestNewDocumentTreeFromHashes(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	var hashes [][]byte
+	for _, leaf := range doctree.GetLeaves() {
+		hashes = append(hashes, leaf.Hash)
+	}
+	rebuilt, err := NewDocumentTreeFromHashes(TreeOptions{Hash: sha256Hash}, doctree.PropertyOrder(), hashes)
+	assert.NoError(t, err)
+	assert.Equal(t, doctree.RootHash(), rebuilt.RootHash())
+
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	valid, err := rebuilt.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = NewDocumentTreeFromHashes(TreeOptions{Hash: sha256Hash}, doctree.PropertyOrder(), hashes[1:])
+	assert.EqualError(t, err, fmt.Sprintf("got %d properties but %d hashes", len(hashes), len(hashes)-1))
+}
+
+func TestTree_PadToPowerOfTwo(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, PadToPowerOfTwo: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledRepeatedDocument))
+	assert.Len(t, doctree.GetLeaves(), 5)
+	assert.NoError(t, doctree.Generate())
+
+	// the tree equals a tree with a fixed depth of 3
+	fixed, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: 3})
+	assert.NoError(t, err)
+	assert.NoError(t, fixed.AddLeavesFromDocument(&documentspb.ExampleFilledRepeatedDocument))
+	assert.NoError(t, fixed.Generate())
+	assert.Equal(t, fixed.RootHash(), doctree.RootHash())
+
+	for _, prop := range doctree.PropertyOrder() {
+		proof, err := doctree.CreateProof(prop.ReadableName())
+		assert.NoError(t, err)
+		assert.Len(t, proof.Hashes, 3)
+		valid, err := doctree.ValidateProof(&proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	_, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, PadToPowerOfTwo: true, EnableHashSorting: true})
+	assert.EqualError(t, err, "PadToPowerOfTwo can't be combined with TreeDepth or EnableHashSorting")
+}
+
+func TestTree_CreateProofByHexPath(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+	assert.NoError(t, doctree.Generate())
+
+	_, leaf := doctree.GetLeafByProperty("valueC[1].valueA")
+	hexPath := "0x" + hex.EncodeToString(leaf.Property.CompactName())
+	proof, name, err := doctree.CreateProofByHexPath(hexPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "valueC[1].valueA", name)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, name, err = doctree.CreateProofByHexPath(hexPath[2:])
+	assert.NoError(t, err)
+	assert.Equal(t, "valueC[1].valueA", name)
+
+	_, _, err = doctree.CreateProofByHexPath("0x0zz1")
+	assert.EqualError(t, err, "malformed hex path 0x0zz1: encoding/hex: invalid byte: U+007A 'z'")
+
+	_, _, err = doctree.CreateProofByHexPath("0x000000ff")
+	assert.EqualError(t, err, "No such field: 000000ff in obj")
+}
+
+func TestRegisterWellKnownEncoder(t *testing.T) {
+	RegisterWellKnownEncoder("google.protobuf.Duration", func(message proto.Message) ([]byte, error) {
+		seconds := make([]byte, 8)
+		binary.BigEndian.PutUint64(seconds, uint64(message.(*duration.Duration).Seconds))
+		return seconds, nil
+	})
+	defer delete(wellKnownEncoders, "google.protobuf.Duration")
+
+	doc := &documentspb.DurationDocument{Name: "foo", Timeout: &duration.Duration{Seconds: 90, Nanos: 5}}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+	assert.Len(t, doctree.GetLeaves(), 2)
+
+	proof, err := doctree.CreateProof("timeout")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 90}, proof.Value)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// dynamic messages are passed to the encoder as their generated type
+	dynamic, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, dynamic.AddLeavesFromMessage(toDynamic(t, doc)))
+	assert.NoError(t, dynamic.Generate())
+	assert.Equal(t, doctree.RootHash(), dynamic.RootHash())
+
+	// unset fields don't add a leaf
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.DurationDocument{Name: "foo"}))
+	assert.Len(t, doctree.GetLeaves(), 1)
+}
+
+func TestTree_VerifyAgainstStoredRoot(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	stored := doctree.RootHash()
+
+	regenerated, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, regenerated.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, regenerated.VerifyAgainstStoredRoot(stored))
+
+	altered := proto.Clone(&documentspb.LongDocumentExample).(*documentspb.LongDocument)
+	altered.Value1 = 42
+	regenerated, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, regenerated.AddLeavesFromDocument(altered))
+	err = regenerated.VerifyAgainstStoredRoot(stored)
+	assert.EqualError(t, err, fmt.Sprintf("root %x does not match the stored root %x", regenerated.RootHash(), stored))
+}
+
+func TestTree_CompactNames(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	assert.Empty(t, doctree.CompactNames())
+	err = doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument)
+	assert.Nil(t, err)
+
+	propOrder := doctree.PropertyOrder()
+	compactNames := doctree.CompactNames()
+	assert.Len(t, compactNames, len(propOrder))
+	for i, prop := range propOrder {
+		assert.Equal(t, prop.CompactName(), compactNames[i])
+	}
+	assert.Equal(t, []byte{0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 1}, compactNames[4])
+}
+
+func TestCreateProof_standard(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	doc := documentspb.FilledExampleDocument
+	doc.ValueNotHashed = sha256Hash.Sum([]byte("some hash"))
+	doc.ValueBytes1 = []byte("ValueBytes1")
+	err = doctree.AddLeavesFromDocument(&doc)
+	assert.Nil(t, err)
+
+	proof, err := doctree.CreateProof("valueA")
+	assert.EqualError(t, err, "Can't create proof before generating merkle root")
+
+	err = doctree.Generate()
+	assert.Nil(t, err)
+
+	_, err = doctree.CreateProof("InexistentField")
 	assert.EqualError(t, err, "No such field: InexistentField in obj")
 
-	proof, err := doctree.CreateProof("valueC[1]")
+	proof, err = doctree.CreateProof("valueA")
 	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("valueC[1]"), proof.Property)
-	assert.Equal(t, []byte(documentspb.ExampleFilledRepeatedDocument.ValueC[1]), proof.Value)
+	assert.Equal(t, ReadableName("valueA"), proof.Property)
+	assert.Equal(t, []byte(documentspb.FilledExampleDocument.ValueA), proof.Value)
 	assert.Equal(t, testSalt, proof.Salt)
 
+	proofB, err := doctree.CreateProof("value_bytes1")
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("value_bytes1"), proofB.Property)
+	assert.Equal(t, doc.ValueBytes1, proofB.Value)
+	assert.Equal(t, testSalt, proofB.Salt)
+
 	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
-	rootHash := []byte{0x16, 0xce, 0xc4, 0xa8, 0xb5, 0xf, 0xe4, 0xf4, 0x1a, 0x47, 0x4, 0xfa, 0xe0, 0x3f, 0x45, 0x7f, 0xad, 0x8e, 0x6b, 0x8e, 0x1c, 0xff, 0x2c, 0x7b, 0x47, 0x4f, 0xbb, 0x36, 0xc0, 0x74, 0xef, 0x70}
+	rootHash := []byte{0x8d, 0xb4, 0x0, 0xfe, 0x35, 0x17, 0xf0, 0x23, 0xa3, 0xe7, 0x29, 0xa, 0x8b, 0x92, 0x5e, 0x1b, 0xde, 0xee, 0x47, 0xe8, 0x81, 0x93, 0x3e, 0xfe, 0x7f, 0x27, 0x6a, 0x90, 0x6f, 0x6d, 0x84, 0x28}
 	assert.Equal(t, rootHash, doctree.rootHash)
-	valid, err := ValidateProofSortedHashes(fieldHash, proof.SortedHashes, rootHash, doctree.hash)
+	valid, err := ValidateProofHashes(fieldHash, proof.Hashes, rootHash, doctree.hash)
+	assert.True(t, valid)
+
+	valid, err = doctree.ValidateProof(&proof)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	valid, err = doctree.ValidateProof(&proofB)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	falseProof, err := doctree.CreateProof("valueA")
+	falseProof.Value = []byte{}
+	valid, err = doctree.ValidateProof(&falseProof)
+	assert.False(t, valid)
+	assert.EqualError(t, err, "Hash does not match")
+}
+
+func TestCreateProof_compact(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	doc := documentspb.FilledExampleDocument
+	doc.ValueNotHashed = sha256Hash.Sum([]byte("some hash"))
+	doc.ValueBytes1 = []byte("ValueBytes1")
+	err = doctree.AddLeavesFromDocument(&doc)
+	assert.Nil(t, err)
+
+	proof, err := doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("valueA"))
+	assert.EqualError(t, err, "Can't create proof before generating merkle root")
+
+	err = doctree.Generate()
+	assert.Nil(t, err)
+
+	_, err = doctree.CreateProofWithCompactProp([]byte{1, 1, 1, 1})
+	assert.EqualError(t, err, "No such field: 01010101 in obj")
+
+	proof, err = doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("valueA"))
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("valueA"), proof.Property)
+	assert.Equal(t, []byte(documentspb.FilledExampleDocument.ValueA), proof.Value)
+	assert.Equal(t, testSalt, proof.Salt)
+
+	proofB, err := doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("value_bytes1"))
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("value_bytes1"), proofB.Property)
+	assert.Equal(t, doc.ValueBytes1, proofB.Value)
+	assert.Equal(t, testSalt, proofB.Salt)
+
+	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
+	rootHash := []byte{0x8d, 0xb4, 0x0, 0xfe, 0x35, 0x17, 0xf0, 0x23, 0xa3, 0xe7, 0x29, 0xa, 0x8b, 0x92, 0x5e, 0x1b, 0xde, 0xee, 0x47, 0xe8, 0x81, 0x93, 0x3e, 0xfe, 0x7f, 0x27, 0x6a, 0x90, 0x6f, 0x6d, 0x84, 0x28}
+	assert.Equal(t, rootHash, doctree.rootHash)
+	valid, err := ValidateProofHashes(fieldHash, proof.Hashes, rootHash, doctree.hash)
+	assert.True(t, valid)
+
+	valid, err = doctree.ValidateProof(&proof)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	valid, err = doctree.ValidateProof(&proofB)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	falseProof, err := doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("valueA"))
+	falseProof.Value = []byte{}
+	valid, err = doctree.ValidateProof(&falseProof)
+	assert.False(t, valid)
+	assert.EqualError(t, err, "Hash does not match")
+
+	// nested
+	docNested := documentspb.ExampleFilledNestedRepeatedDocument
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&docNested)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+	assert.Nil(t, err)
+	s := doctree.GetCompactPropByPropertyName("valueD.valueB")
+	proof, err = doctree.CreateProofWithCompactProp(s)
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("valueD.valueB"), proof.Property)
+	assert.Equal(t, []byte(documentspb.ExampleFilledNestedRepeatedDocument.ValueD.ValueB), proof.Value)
+	assert.Equal(t, testSalt, proof.Salt)
+}
+
+func TestCreateProof_standard_compactProperties(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, CompactProperties: true, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	doc := documentspb.FilledExampleDocument
+	doc.ValueBytes1 = []byte("ValueBytes1")
+	err = doctree.AddLeavesFromDocument(&doc)
+	assert.Nil(t, err)
+
+	proof, err := doctree.CreateProof("valueA")
+	assert.EqualError(t, err, "Can't create proof before generating merkle root")
+
+	err = doctree.Generate()
+	assert.Nil(t, err)
+
+	_, err = doctree.CreateProof("InexistentField")
+	assert.EqualError(t, err, "No such field: InexistentField in obj")
+
+	proof, err = doctree.CreateProof("valueA")
+	assert.Nil(t, err)
+	assert.Equal(t, CompactName(0, 0, 0, 1), proof.Property)
+	assert.Equal(t, []byte(documentspb.FilledExampleDocument.ValueA), proof.Value)
+	assert.Equal(t, testSalt, proof.Salt)
+
+	proofB, err := doctree.CreateProofWithCompactProp(doctree.GetCompactPropByPropertyName("value_bytes1"))
+	assert.Nil(t, err)
+	assert.Equal(t, CompactName(0, 0, 0, 5), proofB.Property)
+	assert.Equal(t, doc.ValueBytes1, proofB.Value)
+	assert.Equal(t, testSalt, proofB.Salt)
+
+	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
+	rootHash := []byte{0xfa, 0x54, 0x43, 0x87, 0xc7, 0x3c, 0x64, 0xc9, 0x77, 0x6a, 0x9a, 0x9a, 0x79, 0xb2, 0xdf, 0xa, 0x71, 0x71, 0xd0, 0xfc, 0x14, 0xf0, 0xbd, 0x45, 0x48, 0x50, 0xb4, 0x36, 0xf2, 0xac, 0xe3, 0x46}
+	assert.Equal(t, rootHash, doctree.rootHash)
+	valid, err := ValidateProofHashes(fieldHash, proof.Hashes, rootHash, doctree.hash)
+	assert.True(t, valid)
+
+	valid, err = doctree.ValidateProof(&proof)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	valid, err = doctree.ValidateProof(&proofB)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	falseProof, err := doctree.CreateProof("valueA")
+	falseProof.Value = []byte{}
+	valid, err = doctree.ValidateProof(&falseProof)
+	assert.False(t, valid)
+	assert.EqualError(t, err, "Hash does not match")
+}
+
+func TestCreateOneofProof(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.ExampleOneofSampleDocument)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+
+	_, err = doctree.CreateProof("valueC")
+	assert.EqualError(t, err, "No such field: valueC in obj")
+
+	proof, err := doctree.CreateProof("valueB")
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("valueB"), proof.Property)
+	ev, err := toBytesArray(documentspb.ExampleOneofSampleDocument.OneofBlock.(*documentspb.OneofSample_ValueB).ValueB)
+	assert.NoError(t, err)
+	assert.Equal(t, ev, proof.Value)
+	assert.Equal(t, testSalt, proof.Salt)
+
+	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
+	rootHash := []byte{0x8, 0x98, 0x8d, 0x75, 0x33, 0xac, 0xc, 0xad, 0x96, 0x2c, 0x9, 0x38, 0x37, 0x2e, 0x44, 0x32, 0x3c, 0x1c, 0xa1, 0xe5, 0xf3, 0x35, 0xdb, 0x21, 0x9f, 0x97, 0x8e, 0x6b, 0x17, 0x4e, 0xa5, 0xa2}
+	assert.Equal(t, rootHash, doctree.rootHash)
+	valid, err := ValidateProofHashes(fieldHash, proof.Hashes, rootHash, doctree.hash)
+	assert.True(t, valid)
+
+	valid, err = doctree.ValidateProof(&proof)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.OneofSample{
+		OneofBlock: &documentspb.OneofSample_ValueC{"bor"},
+	})
+	assert.Nil(t, err)
+	err = doctree.Generate()
+
+	_, err = doctree.CreateProof("valueB")
+	assert.EqualError(t, err, "No such field: valueB in obj")
+	_, err = doctree.CreateProof("valueC")
+	assert.Nil(t, err)
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.OneofSample{
+		OneofBlock: &documentspb.OneofSample_ValueD{&documentspb.SimpleItem{ValueA: "testA"}},
+	})
+	assert.Nil(t, err)
+	err = doctree.Generate()
+
+	_, err = doctree.CreateProof("valueC")
+	assert.EqualError(t, err, "No such field: valueC in obj")
+	_, err = doctree.CreateProof("valueD.valueA")
+	assert.Nil(t, err)
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.OneofSample{})
+	assert.Nil(t, err)
+	err = doctree.Generate()
+
+	_, err = doctree.CreateProof("valueB")
+	assert.EqualError(t, err, "No such field: valueB in obj")
+	_, err = doctree.CreateProof("valueD.valueA")
+	assert.EqualError(t, err, "No such field: valueD.valueA in obj")
+	_, err = doctree.CreateProof("valueC")
+	assert.EqualError(t, err, "No such field: valueC in obj")
+
+}
+
+func TestCreateProof_sorted(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.FilledExampleDocument)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+	assert.Nil(t, err)
+
+	_, err = doctree.CreateProof("InexistentField")
+	assert.EqualError(t, err, "No such field: InexistentField in obj")
+
+	proof, err := doctree.CreateProof("valueA")
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("valueA"), proof.Property)
+	assert.Equal(t, []byte(documentspb.FilledExampleDocument.ValueA), proof.Value)
+	assert.Equal(t, testSalt, proof.Salt)
+
+	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
+	rootHash := []byte{0x54, 0x96, 0x85, 0x35, 0x65, 0x12, 0xb8, 0x63, 0x30, 0x51, 0xb5, 0x1f, 0x79, 0x99, 0x5a, 0x9a, 0x34, 0xc9, 0x34, 0x69, 0xa2, 0xb4, 0xd9, 0xca, 0x7a, 0x4c, 0x1f, 0x8e, 0xeb, 0x73, 0x6f, 0x74}
+	assert.Equal(t, rootHash, doctree.rootHash)
+	valid, err := ValidateProofSortedHashes(fieldHash, proof.SortedHashes, rootHash, doctree.hash)
+	assert.True(t, valid)
+
+	valid, err = doctree.ValidateProof(&proof)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	falseProof, err := doctree.CreateProof("valueA")
+	falseProof.Value = []byte{}
+	valid, err = doctree.ValidateProof(&falseProof)
+	assert.False(t, valid)
+	assert.EqualError(t, err, "Hash does not match")
+}
+
+func TestCreateRepeatedSortedProof(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.ExampleFilledRepeatedDocument)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+
+	_, err = doctree.CreateProof("InexistentField")
+	assert.EqualError(t, err, "No such field: InexistentField in obj")
+
+	proof, err := doctree.CreateProof("valueC[1]")
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("valueC[1]"), proof.Property)
+	assert.Equal(t, []byte(documentspb.ExampleFilledRepeatedDocument.ValueC[1]), proof.Value)
+	assert.Equal(t, testSalt, proof.Salt)
+
+	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
+	rootHash := []byte{0x16, 0xce, 0xc4, 0xa8, 0xb5, 0xf, 0xe4, 0xf4, 0x1a, 0x47, 0x4, 0xfa, 0xe0, 0x3f, 0x45, 0x7f, 0xad, 0x8e, 0x6b, 0x8e, 0x1c, 0xff, 0x2c, 0x7b, 0x47, 0x4f, 0xbb, 0x36, 0xc0, 0x74, 0xef, 0x70}
+	assert.Equal(t, rootHash, doctree.rootHash)
+	valid, err := ValidateProofSortedHashes(fieldHash, proof.SortedHashes, rootHash, doctree.hash)
+	assert.True(t, valid)
+
+	valid, err = doctree.ValidateProof(&proof)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	falseProof, err := doctree.CreateProof("valueC[1]")
+	falseProof.Value = []byte{}
+	valid, err = doctree.ValidateProof(&falseProof)
+	assert.False(t, valid)
+	assert.EqualError(t, err, "Hash does not match")
+}
+
+func TestCreateRepeatedSortedProofAutoSalts(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+	assert.Nil(t, err)
+
+	_, err = doctree.CreateProof("InexistentField")
+	assert.EqualError(t, err, "No such field: InexistentField in obj")
+
+	proof, err := doctree.CreateProof("valueA")
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("valueA"), proof.Property)
+	assert.Equal(t, []byte(documentspb.ExampleFilledRepeatedDocument.ValueA), proof.Value)
+	assert.Equal(t, testSalt, proof.Salt)
+
+	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
+	valid, err := ValidateProofSortedHashes(fieldHash, proof.SortedHashes, doctree.rootHash, doctree.hash)
+	assert.True(t, valid)
+
+	valid, err = doctree.ValidateProof(&proof)
+	assert.True(t, valid)
+	assert.Nil(t, err)
+
+	falseProof, err := doctree.CreateProof("valueA")
+	falseProof.Value = []byte{}
+	valid, err = doctree.ValidateProof(&falseProof)
+	assert.False(t, valid)
+	assert.EqualError(t, err, "Hash does not match")
+}
+
+func TestCreateProofFromRepeatedField(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+	assert.Nil(t, err)
+
+	proof, err := doctree.CreateProof("valueC[1].valueA")
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("valueC[1].valueA"), proof.Property)
+	assert.Equal(t, []byte(documentspb.ExampleFilledNestedRepeatedDocument.ValueC[1].ValueA), proof.Value)
+	assert.Equal(t, testSalt, proof.Salt)
+}
+
+func TestCreateProofFromRepeatedFieldWithParentPrefix(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, ParentPrefix: Property{Text: "doc"}, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+	assert.Nil(t, err)
+
+	proof, err := doctree.CreateProof("doc.valueC[1].valueA")
+	assert.Nil(t, err)
+	assert.Equal(t, ReadableName("doc.valueC[1].valueA"), proof.Property)
+	assert.Equal(t, []byte(documentspb.ExampleFilledNestedRepeatedDocument.ValueC[1].ValueA), proof.Value)
+	assert.Equal(t, testSalt, proof.Salt)
+}
+
+func TestTree_StripPrefixInProof(t *testing.T) {
+	prefix := NewProperty("doc", 1)
+	for _, compact := range []bool{false, true} {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, ParentPrefix: prefix, StripPrefixInProof: true, CompactProperties: compact, Salts: NewSaltForTest})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+		assert.NoError(t, doctree.Generate())
+
+		proof, err := doctree.CreateProof("doc.valueC[1].valueA")
+		assert.NoError(t, err)
+		if compact {
+			assert.Equal(t, CompactName(0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 1), proof.Property)
+		} else {
+			assert.Equal(t, ReadableName("valueC[1].valueA"), proof.Property)
+		}
+		valid, err := doctree.ValidateProof(&proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		// the prefix is still part of the leaf hash
+		unprefixed, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, CompactProperties: compact, Salts: NewSaltForTest})
+		assert.NoError(t, err)
+		assert.NoError(t, unprefixed.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+		assert.NoError(t, unprefixed.Generate())
+		assert.NotEqual(t, unprefixed.RootHash(), doctree.RootHash())
+		valid, err = unprefixed.ValidateProof(&proof)
+		assert.EqualError(t, err, "Hash does not match")
+		assert.False(t, valid)
+	}
+}
+
+func TestCreateProof_HexCompactName(t *testing.T) {
+	address := []byte("abcdefghijklmnopqrst")
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.BytesKeyEntries{
+		Entries: []*documentspb.BytesKeyEntry{{Address: address, Value: "value"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.Generate())
+
+	prop, err := Empty.FieldProp("entries", 1).MapElemProp(address, 20)
+	assert.NoError(t, err)
+	proof, err := doctree.CreateProof("0x" + hex.EncodeToString(prop.CompactName()))
+	assert.NoError(t, err)
+	assert.Equal(t, ReadableName(prop.ReadableName()), proof.Property)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = doctree.CreateProof("0x00000002")
+	assert.EqualError(t, err, "No such field: 00000002 in obj")
+	_, err = doctree.CreateProof("0xnothex")
+	assert.EqualError(t, err, "No such field: 0xnothex in obj")
+}
+
+func TestTree_LeafTransform(t *testing.T) {
+	lowercase := func(prop Property, value []byte) ([]byte, error) {
+		return bytes.ToLower(value), nil
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, LeafTransform: lowercase})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.SimpleRepeatedDocument{ValueA: "FOO", ValueB: "Bar", ValueC: []string{"BAZ"}}))
+	assert.NoError(t, doctree.Generate())
+
+	lowered, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, lowered.AddLeavesFromDocument(&documentspb.SimpleRepeatedDocument{ValueA: "foo", ValueB: "bar", ValueC: []string{"baz"}}))
+	assert.NoError(t, lowered.Generate())
+	assert.Equal(t, lowered.RootHash(), doctree.RootHash())
+
+	proof, err := doctree.CreateProof("valueA")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("foo"), proof.Value)
+	valid, err := lowered.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	failing := func(prop Property, value []byte) ([]byte, error) {
+		return nil, errors.New("transform failed")
+	}
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, LeafTransform: failing})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.SimpleRepeatedDocument{ValueA: "FOO"})
+	assert.EqualError(t, err, "error handling field ValueA: failed to transform value of valueA: transform failed")
+}
+
+func TestTree_StrictEnums(t *testing.T) {
+	doc := &documentspb.ExampleDocument{ValueA: "Foo", EnumType: documentspb.Enum(42)}
+
+	// by default the numeric value is used
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, StrictEnums: true})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromDocument(doc)
+	assert.EqualError(t, err, "error handling field EnumType: enum value 42 is not defined in documents.Enum")
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, StrictEnums: true})
+	assert.NoError(t, err)
+	doc.EnumType = documentspb.Enum_type_two
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+}
+
+func TestTreeOptions_AffectsRoot(t *testing.T) {
+	affectsRoot := TreeOptions{}.AffectsRoot()
+	for _, name := range []string{"Hash", "LeafHash", "EnableHashSorting", "CompactProperties", "FixedLengthFieldLeftPadding", "ReadablePropertyLengthSuffix", "ParentPrefix", "TreeDepth"} {
+		assert.Contains(t, affectsRoot, name)
+	}
+	for _, name := range []string{"StripPrefixInProof", "StrictEnums"} {
+		assert.NotContains(t, affectsRoot, name)
+	}
+
+	// every option needs to be classified
+	classified := append(affectsRoot, cosmeticOptions...)
+	optsType := reflect.TypeOf(TreeOptions{})
+	assert.Len(t, classified, optsType.NumField())
+	for i := 0; i < optsType.NumField(); i++ {
+		assert.Contains(t, classified, optsType.Field(i).Name)
+	}
+}
+
+func TestTree_CreateEmptyLeafProof(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: 3})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ContainSalts{ValueA: "foo", ValueB: 1}))
+
+	_, err = doctree.CreateEmptyLeafProof(2)
+	assert.EqualError(t, err, "Can't create proof before generating merkle root")
+	assert.NoError(t, doctree.Generate())
+
+	emptyHash := sha256.Sum256([]byte{})
+	for index := 2; index < 8; index++ {
+		proof, err := doctree.CreateEmptyLeafProof(index)
+		assert.NoError(t, err)
+		assert.Nil(t, proof.Property)
+		assert.Equal(t, emptyHash[:], proof.Hash)
+		assert.Len(t, proof.Hashes, 3)
+		valid, err := doctree.ValidateProof(&proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	_, err = doctree.CreateEmptyLeafProof(1)
+	assert.EqualError(t, err, "leaf 1 is not empty")
+	_, err = doctree.CreateEmptyLeafProof(8)
+	assert.EqualError(t, err, "leaf 8 is out of range")
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	_, err = doctree.CreateEmptyLeafProof(8)
+	assert.EqualError(t, err, "empty leaf proofs require a fixed depth tree")
+}
+
+func TestTree_CreateAppendFieldProof(t *testing.T) {
+	doc := &documentspb.AppendFieldDocument{
+		Name: &documentspb.Name{
+			First: "john",
+			Last:  "doe",
+		},
+		Names: []*documentspb.Name{{First: "bob", Last: "barker"}},
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+
+	proof, components, err := doctree.CreateAppendFieldProof("name")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("johndoe"), proof.Value)
+	assert.Equal(t, []AppendedField{
+		{Name: "first", FieldNum: 1, Start: 0, End: 4},
+		{Name: "last", FieldNum: 2, Start: 4, End: 7},
+	}, components)
+	assert.Equal(t, []byte("john"), proof.Value[components[0].Start:components[0].End])
+	assert.Equal(t, []byte("doe"), proof.Value[components[1].Start:components[1].End])
+	valid, err := doctree.ValidateProof(proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	proof, components, err = doctree.CreateAppendFieldProof("names[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("barker"), proof.Value[components[1].Start:components[1].End])
+
+	_, _, err = doctree.CreateAppendFieldProof("names.length")
+	assert.EqualError(t, err, "names.length is not created from appended fields or its value was transformed or hashed")
+	_, _, err = doctree.CreateAppendFieldProof("nothing")
+	assert.EqualError(t, err, "No such field: nothing in obj")
+
+	// the ranges don't apply to hashed or transformed values
+	for _, opts := range []TreeOptions{
+		{Hash: sha256Hash, Salts: NewSaltForTest, HashValuesOver: 4},
+		{Hash: sha256Hash, Salts: NewSaltForTest, LeafTransform: func(prop Property, value []byte) ([]byte, error) {
+			return bytes.ToUpper(value), nil
+		}},
+	} {
+		doctree, err = NewDocumentTree(opts)
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+		assert.NoError(t, doctree.Generate())
+		_, leaf := doctree.GetLeafByProperty("name")
+		assert.Nil(t, leaf.Components)
+		_, _, err = doctree.CreateAppendFieldProof("name")
+		assert.EqualError(t, err, "name is not created from appended fields or its value was transformed or hashed")
+	}
+}
+
+func TestTree_NestedAppendFields(t *testing.T) {
+	doc := &documentspb.NestedAppendDocument{
+		Person: &documentspb.NestedAppendInner{
+			First: "john",
+			Name:  &documentspb.Name{First: "jo", Last: "doe"},
+			Age:   42,
+		},
+	}
+	expected := append([]byte("johnjodoe"), 0, 0, 0, 42)
+	var root []byte
+	for i := 0; i < 10; i++ {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+		assert.NoError(t, doctree.Generate())
+		assert.Len(t, doctree.GetLeaves(), 1)
+		if root != nil {
+			assert.Equal(t, root, doctree.RootHash())
+		}
+		root = doctree.RootHash()
+
+		proof, components, err := doctree.CreateAppendFieldProof("person")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, proof.Value)
+		assert.Equal(t, []AppendedField{
+			{Name: "first", FieldNum: 1, Start: 0, End: 4},
+			{Name: "name", FieldNum: 2, Start: 4, End: 9},
+			{Name: "age", FieldNum: 3, Start: 9, End: 13},
+		}, components)
+		valid, err := doctree.ValidateProof(proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	// an unset nested message doesn't add any bytes
+	doc.Person.Name = nil
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	_, leaf := doctree.GetLeafByProperty("person")
+	assert.Equal(t, append([]byte("john"), 0, 0, 0, 42), leaf.Value)
+
+	// HashValuesOver only hashes the merged value of the parent, the nested value stays part of it
+	doc.Person.Name = &documentspb.Name{First: "jo", Last: "doe"}
+	for _, addLeaves := range []func(doctree *DocumentTree) error{
+		func(doctree *DocumentTree) error { return doctree.AddLeavesFromDocument(doc) },
+		func(doctree *DocumentTree) error { return doctree.AddLeavesFromMessage(doc) },
+	} {
+		doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, HashValuesOver: 4})
+		assert.NoError(t, err)
+		assert.NoError(t, addLeaves(&doctree))
+		_, leaf = doctree.GetLeafByProperty("person")
+		assert.True(t, leaf.Hashed)
+		assert.Equal(t, hashBytes(sha256Hash, expected), leaf.Hash)
+	}
+}
+
+func TestTree_IncludeUnknownFields(t *testing.T) {
+	// valueA = "foo" followed by the unknown varint field 5 = 7
+	doc := new(documentspb.SimpleItem)
+	assert.NoError(t, proto.Unmarshal([]byte{0x0a, 0x03, 'f', 'o', 'o', 0x28, 0x07}, doc))
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.Len(t, doctree.GetLeaves(), 1)
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, IncludeUnknownFields: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+	assert.Equal(t, []Property{Empty.FieldProp(UnknownFieldsName, 0), Empty.FieldProp("valueA", 1)}, doctree.PropertyOrder())
+
+	proof, err := doctree.CreateProof(UnknownFieldsName)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x28, 0x07}, proof.Value)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// no leaf without unknown fields
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, IncludeUnknownFields: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.SimpleItem{ValueA: "foo"}))
+	assert.Len(t, doctree.GetLeaves(), 1)
+}
+
+func TestTree_NodeHasher(t *testing.T) {
+	lengthPrefixed := func(a, b []byte, hashFunc hash.Hash) []byte {
+		data := append([]byte{byte(len(a))}, a...)
+		data = append(data, byte(len(b)))
+		return hashBytes(hashFunc, append(data, b...))
+	}
+	for _, opts := range []TreeOptions{
+		{Hash: sha256Hash, Salts: NewSaltForTest},
+		{Hash: sha256Hash, Salts: NewSaltForTest, EnableHashSorting: true},
+		{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: 5},
+	} {
+		defaultTree, err := NewDocumentTree(opts)
+		assert.NoError(t, err)
+		assert.NoError(t, defaultTree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+		assert.NoError(t, defaultTree.Generate())
+
+		opts.NodeHasher = lengthPrefixed
+		doctree, err := NewDocumentTree(opts)
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+		assert.NoError(t, doctree.Generate())
+		assert.NotEqual(t, defaultTree.RootHash(), doctree.RootHash())
+
+		for _, leaf := range doctree.GetLeaves() {
+			proof, err := doctree.CreateProof(leaf.Property.ReadableName())
+			assert.NoError(t, err)
+			valid, err := doctree.ValidateProof(&proof)
+			assert.NoError(t, err)
+			assert.True(t, valid)
+
+			_, err = defaultTree.ValidateProof(&proof)
+			assert.EqualError(t, err, "Hash does not match")
+		}
+	}
+}
+
+type testAnyResolver struct{}
+
+func (testAnyResolver) Resolve(typeURL string) (proto.Message, error) {
+	if typeURL != "type.googleapis.com/documents.Name" {
+		return nil, fmt.Errorf("unknown type %s", typeURL)
+	}
+	return new(documentspb.Name), nil
+}
+
+func TestTree_WrapperTypes(t *testing.T) {
+	doc := &documentspb.WrapperDocument{
+		Name:   &wrappers.StringValue{Value: "john"},
+		Amount: &wrappers.Int64Value{Value: 42},
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+
+	// unset wrappers don't add a leaf, like unset messages
+	var names []string
+	for _, prop := range doctree.PropertyOrder() {
+		names = append(names, prop.ReadableName())
+	}
+	assert.Equal(t, []string{"amount", "name"}, names)
+
+	proof, err := doctree.CreateProof("amount")
+	assert.NoError(t, err)
+	value, err := toBytesArray(int64(42))
+	assert.NoError(t, err)
+	assert.Equal(t, value, proof.Value)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	proof, err = doctree.CreateProof("name")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("john"), proof.Value)
+	valid, err = doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestTree_AnyResolver(t *testing.T) {
+	payload, err := ptypes.MarshalAny(&documentspb.Name{First: "john", Last: "doe"})
+	assert.NoError(t, err)
+	doc := &documentspb.AnyDocument{ValueA: "foo", Payload: payload}
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, AnyResolver: testAnyResolver{}})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+	var names []string
+	for _, prop := range doctree.PropertyOrder() {
+		names = append(names, prop.ReadableName())
+	}
+	assert.Equal(t, []string{"payload.first", "payload.last", "valueA"}, names)
+
+	proof, err := doctree.CreateProof("payload.first")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("john"), proof.Value)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// without a resolver the Any is flattened as a message
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	_, leaf := doctree.GetLeafByProperty("payload.type_url")
+	assert.NotNil(t, leaf)
+
+	payload.TypeUrl = "type.googleapis.com/documents.Unknown"
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, AnyResolver: testAnyResolver{}})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromDocument(doc)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown type type.googleapis.com/documents.Unknown")
+}
+
+func TestRequiredTreeDepth(t *testing.T) {
+	leaves, err := FlattenMessage(&documentspb.LongDocumentExample, NewSaltForTest, DefaultReadablePropertyLengthSuffix, sha256Hash, false, Empty, false)
+	assert.NoError(t, err)
+	depth, count, err := RequiredTreeDepth(&documentspb.LongDocumentExample, TreeOptions{Hash: sha256Hash, TreeDepth: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, len(leaves), count)
+	assert.Equal(t, 15, count)
+	assert.Equal(t, uint(4), depth)
+	assert.Nil(t, documentspb.LongDocumentExample.Salts)
+
+	// the document fits into a tree of the returned depth
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: depth})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: depth - 1})
+	assert.NoError(t, err)
+	assert.EqualError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample), "tree already has enough leaves")
+
+	// a TreeDepth of 0 wouldn't fix the size
+	depth, count, err = RequiredTreeDepth(&documentspb.SimpleItem{ValueA: "foo"}, TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, uint(1), depth)
+
+	// the leaf count leaf is counted
+	depth, count, err = RequiredTreeDepth(&documentspb.LongDocumentExample, TreeOptions{Hash: sha256Hash, CommitLeafCount: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 16, count)
+	assert.Equal(t, uint(4), depth)
+	depth, count, err = RequiredTreeDepth(&documentspb.SimpleItem{ValueA: "foo"}, TreeOptions{Hash: sha256Hash, CommitLeafCount: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, uint(1), depth)
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: depth, CommitLeafCount: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.SimpleItem{ValueA: "foo"}))
+	assert.NoError(t, doctree.Generate())
+}
+
+func TestTree_AllNodeHashes(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleDocument{ValueA: "foo", ValueB: "bar", Value1: 1}))
+	assert.Nil(t, doctree.AllNodeHashes())
+	assert.NoError(t, doctree.Generate())
+
+	nodes := doctree.AllNodeHashes()
+	_, nodeCount := calculateHeightAndNodeCount(uint64(len(doctree.leaves)))
+	assert.Len(t, nodes, int(nodeCount))
+	assert.Equal(t, doctree.leaves[0].Hash, nodes[0])
+	assert.Equal(t, doctree.RootHash(), nodes[len(nodes)-1])
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: 8})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleDocument{ValueA: "foo", ValueB: "bar", Value1: 1}))
+	assert.NoError(t, doctree.Generate())
+	nodes = doctree.AllNodeHashes()
+	assert.Equal(t, doctree.RootHash(), nodes[len(nodes)-1])
+}
+
+func TestTree_CreateProofMatchesHash(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	_, leaf := doctree.GetLeafByProperty("valueA")
+
+	proof, matches, err := doctree.CreateProofMatchesHash("valueA", leaf.Hash)
+	assert.NoError(t, err)
+	assert.True(t, matches)
+	assert.Equal(t, ReadableName("valueA"), proof.Property)
+
+	other := sha256.Sum256([]byte("other"))
+	proof, matches, err = doctree.CreateProofMatchesHash("valueA", other[:])
+	assert.NoError(t, err)
+	assert.False(t, matches)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, _, err = doctree.CreateProofMatchesHash("valueX", leaf.Hash)
+	assert.EqualError(t, err, "No such field: valueX in obj")
+}
+
+func TestTree_CreateSelectiveDisclosure(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, EnableHashSorting: true, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	disclosure, err := doctree.CreateSelectiveDisclosure([]string{"value1", "valueA"})
+	assert.NoError(t, err)
+	assert.Equal(t, doctree.RootHash(), disclosure.RootHash)
+	assert.True(t, disclosure.Sorted)
+	assert.Len(t, disclosure.Proofs, 2)
+	assert.NoError(t, disclosure.Validate(sha256Hash))
+
+	disclosure.Proofs[1].Value = []byte{1}
+	assert.EqualError(t, disclosure.Validate(sha256Hash), "invalid proof for valueA: Hash does not match")
+
+	_, err = doctree.CreateSelectiveDisclosure([]string{"valueX"})
+	assert.EqualError(t, err, "No such field: valueX in obj")
+
+	// unsalted fields have to be revealed
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.NoSaltDocument{ValueNoSalt: "foo", ValueSalt: "bar"}))
+	assert.NoError(t, doctree.Generate())
+	_, err = doctree.CreateSelectiveDisclosure([]string{"valueSalt"})
+	assert.EqualError(t, err, "valueNoSalt is not salted and could be recovered from the disclosure")
+	disclosure, err = doctree.CreateSelectiveDisclosure([]string{"valueSalt", "valueNoSalt"})
+	assert.NoError(t, err)
+	assert.NoError(t, disclosure.Validate(sha256Hash))
+}
+
+func TestTree_CreateProofWithFieldNums(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, CompactProperties: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	_, leaf := doctree.GetLeafByCompactProperty(proof.GetCompactName())
+	fieldNums, err := leaf.Property.FieldNums()
+	assert.NoError(t, err)
+
+	fromFieldNums, err := doctree.CreateProofWithFieldNums(fieldNums)
+	assert.NoError(t, err)
+	assert.Equal(t, proof.Property, fromFieldNums.Property)
+	assert.Equal(t, proof.Value, fromFieldNums.Value)
+	assert.Equal(t, proof.Hashes, fromFieldNums.Hashes)
+	valid, err := doctree.ValidateProof(&fromFieldNums)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = doctree.CreateProofWithFieldNums(&proofspb.FieldNums{Components: []uint64{1000}})
+	assert.EqualError(t, err, "No such field: [1000] in obj")
+}
+
+func TestNewSpecSalts(t *testing.T) {
+	vectors := []struct {
+		seed, compact, salt string
+	}{
+		{"73656564", "00000001", "73a649427664d03bbb062e456425488416c52c64ef46fe011ed2a983f30ea9b9"},
+		{"0000000000000000000000000000000000000000000000000000000000000000", "000000030000000000000002", "632173c31a41c6cabb49f6a90b49a311ccdb1249d81f55d0734099a32cd4f088"},
+	}
+	for _, v := range vectors {
+		seed, _ := hex.DecodeString(v.seed)
+		compact, _ := hex.DecodeString(v.compact)
+		salt, err := NewSpecSalts(seed)(compact)
+		assert.NoError(t, err)
+		assert.Equal(t, v.salt, hex.EncodeToString(salt))
+	}
+
+	seed := []byte("seed")
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSpecSalts(seed)})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleDocument{ValueA: "foo"}))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("valueA")
+	assert.NoError(t, err)
+	assert.Equal(t, "73a649427664d03bbb062e456425488416c52c64ef46fe011ed2a983f30ea9b9", hex.EncodeToString(proof.Salt))
+}
+
+type memorySaltStore struct {
+	salts map[string][]*proofspb.Salt
+	saves int
+}
+
+func (s *memorySaltStore) Load(docID string) (Salts, error) {
+	salts, ok := s.salts[docID]
+	if !ok {
+		return nil, nil
+	}
+	return func(compact []byte) ([]byte, error) {
+		for _, salt := range salts {
+			if bytes.Equal(salt.Compact, compact) {
+				return salt.Value, nil
+			}
+		}
+		return nil, nil
+	}, nil
+}
+
+func (s *memorySaltStore) Save(docID string, salts []*proofspb.Salt) error {
+	s.salts[docID] = salts
+	s.saves++
+	return nil
+}
+
+func TestNewDocumentTreeWithSaltStore(t *testing.T) {
+	store := &memorySaltStore{salts: make(map[string][]*proofspb.Salt)}
+	build := func(doc proto.Message) []byte {
+		doctree, err := NewDocumentTreeWithSaltStore(TreeOptions{Hash: sha256Hash}, store, "doc1")
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+		assert.NoError(t, doctree.Generate())
+		return doctree.RootHash()
+	}
+
+	doc := &documentspb.ExampleWithoutSalts{ValueA: "foo", ValueB: 42}
+	root := build(doc)
+	assert.Equal(t, 1, store.saves)
+	assert.Len(t, store.salts["doc1"], 2)
+	assert.Equal(t, root, build(doc))
+	// all salts were loaded, nothing new to save
+	assert.Equal(t, 1, store.saves)
+
+	// another document id gets its own salts
+	doctree, err := NewDocumentTreeWithSaltStore(TreeOptions{Hash: sha256Hash}, store, "doc2")
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+	assert.NotEqual(t, root, doctree.RootHash())
+
+	_, err = NewDocumentTreeWithSaltStore(TreeOptions{Hash: sha256Hash}, nil, "doc1")
+	assert.EqualError(t, err, "salt store is not set")
+	_, err = NewDocumentTreeWithSaltStore(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest}, store, "doc1")
+	assert.EqualError(t, err, "Salts can't be used together with a salt store")
+}
+
+func TestLeafIndexFromProof(t *testing.T) {
+	doc := &documentspb.LongDocumentExample
+	for _, test := range []struct {
+		opts      TreeOptions
+		leafCount uint64
+	}{
+		{TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: 4}, 16},
+		// the last of the 15 leaves of the unbalanced tree is a lone node
+		{TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest}, 15},
+	} {
+		doctree, err := NewDocumentTree(test.opts)
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+		assert.NoError(t, doctree.Generate())
+		for i, prop := range doctree.PropertyOrder() {
+			proof, err := doctree.CreateProof(prop.ReadableName())
+			assert.NoError(t, err)
+			index, err := LeafIndexFromProof(&proof, test.leafCount)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(i), index, prop.ReadableName())
+		}
+	}
+
+	// leaf 4 of a 5 leaf tree is promoted twice
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, doctree.AddLeaf(LeafNode{Property: Empty.FieldProp(fmt.Sprintf("leaf%d", i), FieldNum(i+1)), Value: []byte{byte(i)}}))
+	}
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("leaf4")
+	assert.NoError(t, err)
+	index, err := LeafIndexFromProof(&proof, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), index)
+	_, err = LeafIndexFromProof(&proof, 0)
+	assert.EqualError(t, err, "leaf count must be positive")
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, EnableHashSorting: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+	proof, err = doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	_, err = LeafIndexFromProof(&proof, 15)
+	assert.EqualError(t, err, "proof uses sorted hashes which don't reveal the leaf position")
+}
+
+func TestVerifyProofWithTrace(t *testing.T) {
+	for _, sorted := range []bool{false, true} {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, EnableHashSorting: sorted})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+		assert.NoError(t, doctree.Generate())
+		proof, err := doctree.CreateProof("value1")
+		assert.NoError(t, err)
+
+		valid, trace, err := VerifyProofWithTrace(&proof, doctree.RootHash(), sha256Hash, sorted)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+		assert.Len(t, trace, len(proof.Hashes)+len(proof.SortedHashes)+2)
+		assert.True(t, strings.HasPrefix(trace[0], "hash leaf value1 = "))
+		assert.Equal(t, fmt.Sprintf("final root = %x matches", doctree.RootHash()), trace[len(trace)-1])
+
+		valid, trace, err = VerifyProofWithTrace(&proof, make([]byte, 32), sha256Hash, sorted)
+		assert.EqualError(t, err, "Hash does not match")
+		assert.False(t, valid)
+		assert.True(t, strings.HasSuffix(trace[len(trace)-1], fmt.Sprintf("does not match %x", make([]byte, 32))))
+	}
+}
+
+func TestTree_Extend(t *testing.T) {
+	full, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, full.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, full.Generate())
+	leaves := full.GetLeaves()
+
+	for m := 1; m <= len(leaves); m++ {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeaves(leaves[:m]))
+		assert.NoError(t, doctree.Generate())
+
+		extended, proof, err := doctree.Extend(leaves[m:])
+		assert.NoError(t, err)
+		assert.Equal(t, full.RootHash(), extended.RootHash())
+		assert.Len(t, doctree.GetLeaves(), m)
+		valid, err := VerifyConsistency(doctree.RootHash(), extended.RootHash(), proof, m, len(leaves), sha256Hash)
+		assert.NoError(t, err, "old size %d", m)
+		assert.True(t, valid)
+
+		// proofs of the new leaves validate against the new root
+		p, err := extended.CreateProof(leaves[len(leaves)-1].Property.ReadableName())
+		assert.NoError(t, err)
+		valid, err = extended.ValidateProof(&p)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	_, _, err = full.Extend(leaves[:1])
+	assert.EqualError(t, err, "duplicated leaf: readable name value0 is already used")
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	_, _, err = doctree.Extend(leaves)
+	assert.EqualError(t, err, "Can't extend a tree before generating merkle root")
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, EnableHashSorting: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeaves(leaves[:2]))
+	assert.NoError(t, doctree.Generate())
+	_, _, err = doctree.Extend(leaves[2:])
+	assert.EqualError(t, err, "trees with a fixed TreeDepth or hash sorting can't be extended")
+}
+
+func TestVerifyConsistency(t *testing.T) {
+	full, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, full.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, full.Generate())
+	leaves := full.GetLeaves()
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeaves(leaves[:6]))
+	assert.NoError(t, doctree.Generate())
+	extended, proof, err := doctree.Extend(leaves[6:])
+	assert.NoError(t, err)
+
+	// valid extension
+	valid, err := VerifyConsistency(doctree.RootHash(), extended.RootHash(), proof, 6, 15, sha256Hash)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// tampered new root
+	tampered := append([]byte(nil), extended.RootHash()...)
+	tampered[0] ^= 1
+	valid, err = VerifyConsistency(doctree.RootHash(), tampered, proof, 6, 15, sha256Hash)
+	assert.EqualError(t, err, "Hash does not match")
+	assert.False(t, valid)
+
+	// wrong sizes
+	valid, err = VerifyConsistency(doctree.RootHash(), extended.RootHash(), proof, 5, 15, sha256Hash)
+	assert.Error(t, err)
+	assert.False(t, valid)
+	valid, err = VerifyConsistency(doctree.RootHash(), extended.RootHash(), proof, 16, 15, sha256Hash)
+	assert.EqualError(t, err, "old size 16 is not between 1 and the new size 15")
+	assert.False(t, valid)
+
+	// the new tree doesn't start with the leaves of the old tree
+	other, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, other.AddLeaves(append(append([]LeafNode(nil), leaves[1:6]...), leaves[0])))
+	assert.NoError(t, other.Generate())
+	reordered, otherProof, err := other.Extend(leaves[6:])
+	assert.NoError(t, err)
+	valid, err = VerifyConsistency(doctree.RootHash(), reordered.RootHash(), otherProof, 6, 15, sha256Hash)
+	assert.EqualError(t, err, "Hash does not match")
+	assert.False(t, valid)
+	valid, err = VerifyConsistency(doctree.RootHash(), reordered.RootHash(), proof, 6, 15, sha256Hash)
+	assert.EqualError(t, err, "Hash does not match")
+	assert.False(t, valid)
+}
+
+func TestTree_CompositeMappingKey(t *testing.T) {
+	doc := &documentspb.CompositeKeyDocument{Entries: []*documentspb.CompositeKeyEntry{
+		{Country: "DE", Year: 2020, Value: "a"},
+		{Country: "DE", Year: 2021, Value: "b"},
+		{Country: "FR", Year: 2020, Value: "c"},
+	}}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+
+	var names []string
+	for _, prop := range doctree.PropertyOrder() {
+		names = append(names, prop.ReadableName())
+	}
+	assert.Equal(t, []string{"entries.length", "entries[DE,2020]", "entries[DE,2021]", "entries[FR,2020]"}, names)
+
+	proof, err := doctree.CreateProof("entries[DE,2021]")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("b"), proof.Value)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, leaf := doctree.GetLeafByProperty("entries[DE,2021]")
+	assert.Equal(t, []byte{0, 0, 0, 1, 2, 'D', 'E', 4, 0, 0, 0x07, 0xe5}, leaf.Property.CompactName())
+
+	_, compactKey, err := doctree.CreateMapEntryProof("entries", []interface{}{"FR", int32(2020)})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2, 'F', 'R', 4, 0, 0, 0x07, 0xe4}, compactKey)
+}
+
+func TestTree_CreateMapEntryProof(t *testing.T) {
+	doc := &documentspb.SimpleStringMap{Value: map[string]string{"foo": "bar", "baz": "qux"}}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+
+	proof, compactKey, err := doctree.CreateMapEntryProof("value", "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, "value[foo]", proof.GetReadableName())
+	assert.Equal(t, append(make([]byte, 29), []byte("foo")...), compactKey)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
 	assert.True(t, valid)
 
-	valid, err = doctree.ValidateProof(&proof)
-	assert.True(t, valid)
-	assert.Nil(t, err)
+	// the compact name of the leaf is the compact name of the field followed by the key
+	_, leaf := doctree.GetLeafByProperty("value[foo]")
+	assert.Equal(t, append(Empty.FieldProp("value", 1).CompactName(), compactKey...), leaf.Property.CompactName())
 
-	falseProof, err := doctree.CreateProof("valueC[1]")
-	falseProof.Value = []byte{}
-	valid, err = doctree.ValidateProof(&falseProof)
-	assert.False(t, valid)
-	assert.EqualError(t, err, "Hash does not match")
+	_, _, err = doctree.CreateMapEntryProof("value", "missing")
+	assert.EqualError(t, err, "No such field: value[missing] in obj")
 }
 
-func TestCreateRepeatedSortedProofAutoSalts(t *testing.T) {
-	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument)
-	assert.Nil(t, err)
-	err = doctree.Generate()
-	assert.Nil(t, err)
+func TestTree_CreateRepeatedFieldProofs(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledRepeatedDocument))
+	assert.NoError(t, doctree.Generate())
 
-	_, err = doctree.CreateProof("InexistentField")
-	assert.EqualError(t, err, "No such field: InexistentField in obj")
+	proofs, err := doctree.CreateRepeatedFieldProofs("valueC")
+	assert.NoError(t, err)
+	assert.Len(t, proofs, 2)
+	for i, value := range documentspb.ExampleFilledRepeatedDocument.ValueC {
+		assert.Equal(t, fmt.Sprintf("valueC[%d]", i), proofs[i].GetReadableName())
+		assert.Equal(t, []byte(value), proofs[i].Value)
+		valid, err := doctree.ValidateProof(&proofs[i])
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
 
-	proof, err := doctree.CreateProof("valueA")
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("valueA"), proof.Property)
-	assert.Equal(t, []byte(documentspb.ExampleFilledRepeatedDocument.ValueA), proof.Value)
-	assert.Equal(t, testSalt, proof.Salt)
+	_, err = doctree.CreateRepeatedFieldProofs("valueA")
+	assert.EqualError(t, err, "No such repeated field: valueA in obj")
 
-	fieldHash, err := CalculateHashForProofField(&proof, sha256Hash)
-	valid, err := ValidateProofSortedHashes(fieldHash, proof.SortedHashes, doctree.rootHash, doctree.hash)
-	assert.True(t, valid)
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+	assert.NoError(t, doctree.Generate())
+	proofs, err = doctree.CreateRepeatedFieldProofs("valueC")
+	assert.NoError(t, err)
+	assert.Len(t, proofs, 2)
+	assert.Equal(t, "valueC[0].valueA", proofs[0].GetReadableName())
+	assert.Equal(t, "valueC[1].valueA", proofs[1].GetReadableName())
+}
 
-	valid, err = doctree.ValidateProof(&proof)
-	assert.True(t, valid)
-	assert.Nil(t, err)
+func TestTree_CreateProofWithAncestry(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+	assert.NoError(t, doctree.Generate())
 
-	falseProof, err := doctree.CreateProof("valueA")
-	falseProof.Value = []byte{}
-	valid, err = doctree.ValidateProof(&falseProof)
-	assert.False(t, valid)
-	assert.EqualError(t, err, "Hash does not match")
-}
+	proofs, err := doctree.CreateProofWithAncestry("valueC[1].valueA")
+	assert.NoError(t, err)
+	assert.Len(t, proofs, 2)
+	assert.Equal(t, "valueC[1].valueA", proofs[0].GetReadableName())
+	assert.Equal(t, "valueC.length", proofs[1].GetReadableName())
+	length := make([]byte, 8)
+	binary.BigEndian.PutUint64(length, uint64(len(documentspb.ExampleFilledNestedRepeatedDocument.ValueC)))
+	assert.Equal(t, length, proofs[1].Value)
+	for i := range proofs {
+		valid, err := doctree.ValidateProof(&proofs[i])
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
 
-func TestCreateProofFromRepeatedField(t *testing.T) {
-	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument)
-	assert.Nil(t, err)
-	err = doctree.Generate()
-	assert.Nil(t, err)
+	proofs, err = doctree.CreateProofWithAncestry("valueA")
+	assert.NoError(t, err)
+	assert.Len(t, proofs, 1)
 
-	proof, err := doctree.CreateProof("valueC[1].valueA")
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("valueC[1].valueA"), proof.Property)
-	assert.Equal(t, []byte(documentspb.ExampleFilledNestedRepeatedDocument.ValueC[1].ValueA), proof.Value)
-	assert.Equal(t, testSalt, proof.Salt)
+	_, err = doctree.CreateProofWithAncestry("valueC[5].valueA")
+	assert.EqualError(t, err, "No such field: valueC[5].valueA in obj")
 }
 
-func TestCreateProofFromRepeatedFieldWithParentPrefix(t *testing.T) {
-	doctree, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256Hash, ParentPrefix: Property{Text: "doc"}, Salts: NewSaltForTest})
-	assert.Nil(t, err)
-	err = doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument)
-	assert.Nil(t, err)
-	err = doctree.Generate()
-	assert.Nil(t, err)
+func TestTree_CreateProofAsPrefix(t *testing.T) {
+	doc := &documentspb.ExampleNested{
+		HashedValue: sha256Hash.Sum([]byte("value")),
+		Name:        &documentspb.Name{First: "john"},
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, ParentPrefix: NewProperty("a", 1)})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
 
-	proof, err := doctree.CreateProof("doc.valueC[1].valueA")
-	assert.Nil(t, err)
-	assert.Equal(t, ReadableName("doc.valueC[1].valueA"), proof.Property)
-	assert.Equal(t, []byte(documentspb.ExampleFilledNestedRepeatedDocument.ValueC[1].ValueA), proof.Value)
-	assert.Equal(t, testSalt, proof.Salt)
+	newPrefix := NewProperty("b", 2)
+	proof, err := doctree.CreateProofAsPrefix("a.hashed_value", newPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, ReadableName("b.hashed_value"), proof.Property)
+	assert.Equal(t, doc.HashedValue, proof.Hash)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// the property name of regular leaves is part of the hash
+	_, err = doctree.CreateProofAsPrefix("a.name.first", newPrefix)
+	assert.EqualError(t, err, "can't change the prefix of a.name.first as the property name is part of its leaf hash")
+	_, err = doctree.CreateProofAsPrefix("b.hashed_value", newPrefix)
+	assert.EqualError(t, err, "No such field: b.hashed_value in obj")
+
+	// trees without prefix
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, CompactProperties: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+	proof, err = doctree.CreateProofAsPrefix("hashed_value", newPrefix)
+	assert.NoError(t, err)
+	assert.Equal(t, CompactName(2, 0, 0, 0, 1), proof.Property)
 }
 
 func TestCreateProofFromNestedField(t *testing.T) {
@@ -1083,6 +3102,50 @@ func Test_integers(t *testing.T) {
 	}
 }
 
+func TestValidateTypedProof(t *testing.T) {
+	doc := &documentspb.ExampleDocument{ValueA: "Example", Value1: 42, Value2: -7}
+	for _, sorted := range []bool{false, true} {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256.New(), Salts: NewSaltForTest, EnableHashSorting: sorted})
+		assert.Nil(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+		assert.NoError(t, doctree.Generate())
+		root := doctree.RootHash()
+
+		proof, err := doctree.CreateProof("valueA")
+		assert.NoError(t, err)
+		proof.Value = nil
+		valid, err := ValidateTypedProof("Example", "string", &proof, root, sha256.New(), sorted)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+		valid, err = ValidateTypedProof("Other", "string", &proof, root, sha256.New(), sorted)
+		assert.EqualError(t, err, "Hash does not match")
+		assert.False(t, valid)
+
+		proof, err = doctree.CreateProof("value1")
+		assert.NoError(t, err)
+		// JSON numbers are decoded as float64
+		var typed map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(`{"value": 42}`), &typed))
+		valid, err = ValidateTypedProof(typed["value"], "int64", &proof, root, sha256.New(), sorted)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+		valid, err = ValidateTypedProof("42", "int64", &proof, root, sha256.New(), sorted)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+		_, err = ValidateTypedProof(42.5, "int64", &proof, root, sha256.New(), sorted)
+		assert.EqualError(t, err, "42.5 is not an int64 value")
+
+		proof, err = doctree.CreateProof("value2")
+		assert.NoError(t, err)
+		valid, err = ValidateTypedProof(int64(-7), "int64", &proof, root, sha256.New(), sorted)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		_, err = ValidateTypedProof(true, "bool", &proof, root, sha256.New(), sorted)
+		assert.EqualError(t, err, "unsupported value type \"bool\"")
+	}
+}
+
 func Test_GenerateSingleLeafTree(t *testing.T) {
 	foobarHash := sha256.Sum256([]byte("foobar"))
 	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
@@ -1101,6 +3164,393 @@ func Test_GenerateSingleLeafTree(t *testing.T) {
 	assert.Equal(t, foobarHash[:], doctree.RootHash())
 }
 
+func TestTree_Arity(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, Arity: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	// the root is calculated by hashing groups of up to 4 nodes
+	level := make([][]byte, len(doctree.leaves))
+	for i, leaf := range doctree.leaves {
+		level[i] = leaf.Hash
+	}
+	for len(level) > 1 {
+		var next [][]byte
+		for i := 0; i < len(level); i += 4 {
+			end := i + 4
+			if end > len(level) {
+				end = len(level)
+			}
+			if end-i == 1 {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, hashBytes(sha256Hash, bytes.Join(level[i:end], nil)))
+		}
+		level = next
+	}
+	assert.Equal(t, level[0], doctree.RootHash())
+
+	for _, prop := range doctree.PropertyOrder() {
+		proof, err := doctree.CreateProof(prop.ReadableName())
+		assert.NoError(t, err)
+		assert.True(t, len(proof.Hashes) <= 2)
+		valid, err := doctree.ValidateProof(&proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	proof.Value = []byte("tampered")
+	valid, err := doctree.ValidateProof(&proof)
+	assert.EqualError(t, err, "Hash does not match")
+	assert.False(t, valid)
+
+	// arity 2 is the default binary tree
+	binaryTree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, Arity: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, binaryTree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, binaryTree.Generate())
+	expected, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, expected.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, expected.Generate())
+	assert.Equal(t, expected.RootHash(), binaryTree.RootHash())
+
+	_, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Arity: 1})
+	assert.EqualError(t, err, "Arity has to be at least 2, got 1")
+	_, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Arity: 4, EnableHashSorting: true})
+	assert.EqualError(t, err, "Arity above 2 can't be combined with TreeDepth, PadToPowerOfTwo, EnableHashSorting, NodeHasher or SchemeVersion")
+	_, _, err = doctree.Extend([]LeafNode{{Property: NewProperty("extra"), Value: []byte("foo")}})
+	assert.EqualError(t, err, "trees with an Arity above 2 can't be extended")
+}
+
+func TestTree_SingleLeafRootMode(t *testing.T) {
+	foobarHash := sha256.Sum256([]byte("foobar"))
+	tests := []struct {
+		mode   SingleLeafRootMode
+		sorted bool
+		root   []byte
+	}{
+		{SingleLeafRootLeafHash, false, foobarHash[:]},
+		{SingleLeafRootHashOfLeaf, false, hashBytes(sha256Hash, foobarHash[:])},
+		{SingleLeafRootHashOfLeaf, true, hashBytes(sha256Hash, foobarHash[:])},
+		{SingleLeafRootDuplicateAndHash, false, HashTwoValues(foobarHash[:], foobarHash[:], sha256Hash)},
+		{SingleLeafRootDuplicateAndHash, true, HashTwoValues(foobarHash[:], foobarHash[:], sha256Hash)},
+	}
+	for _, test := range tests {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, SingleLeafRootMode: test.mode, EnableHashSorting: test.sorted})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeaf(LeafNode{Hash: foobarHash[:], Property: Property{Text: "Foobar1"}, Hashed: true}))
+		assert.NoError(t, doctree.Generate())
+		assert.Equal(t, test.root, doctree.RootHash())
+
+		proof, err := doctree.CreateProof("Foobar1")
+		assert.NoError(t, err)
+		valid, err := doctree.ValidateProof(&proof)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	// trees with more leaves aren't affected
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, SingleLeafRootMode: SingleLeafRootDuplicateAndHash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	assert.Equal(t, doctree.merkleTree.RootHash(), doctree.RootHash())
+}
+
+func TestTree_CommitLeafCount(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, CommitLeafCount: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	proof, err := doctree.CreateLeafCountProof()
+	assert.NoError(t, err)
+	assert.Equal(t, "_leafCount", proof.GetReadableName())
+	assert.Equal(t, uint64(len(doctree.GetLeaves())), binary.BigEndian.Uint64(proof.Value))
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// the unsalted count leaf is not reported as brute forceable
+	for _, warning := range doctree.Warnings() {
+		assert.NotContains(t, warning, "not salted")
+	}
+
+	// the count leaf changes the root
+	plain, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, plain.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, plain.Generate())
+	assert.Len(t, doctree.GetLeaves(), len(plain.GetLeaves())+1)
+	assert.NotEqual(t, plain.RootHash(), doctree.RootHash())
+	_, err = plain.CreateLeafCountProof()
+	assert.EqualError(t, err, "the tree doesn't commit its leaf count, set CommitLeafCount")
+
+	_, _, err = doctree.Extend([]LeafNode{{Property: NewProperty("extra"), Value: []byte("foo")}})
+	assert.EqualError(t, err, "trees with CommitLeafCount can't be extended")
+}
+
+func TestTree_SaltLength(t *testing.T) {
+	shortSalts := func(compact []byte) ([]byte, error) {
+		return testSalt[:16], nil
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: shortSalts, SaltLength: 16})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	assert.Len(t, proof.Salt, 16)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// shorter salts are zero-extended
+	extended, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: shortSalts, SaltLength: 32})
+	assert.NoError(t, err)
+	assert.NoError(t, extended.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, extended.Generate())
+	padded, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: func(compact []byte) ([]byte, error) {
+		return append(append([]byte{}, testSalt[:16]...), make([]byte, 16)...), nil
+	}})
+	assert.NoError(t, err)
+	assert.NoError(t, padded.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, padded.Generate())
+	assert.Equal(t, padded.RootHash(), extended.RootHash())
+	proof, err = extended.CreateProof("value1")
+	assert.NoError(t, err)
+	valid, err = extended.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// generated salts have SaltLength bytes
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, SaltLength: 16})
+	assert.NoError(t, err)
+	doc := &documentspb.ExampleDocument{ValueA: "foo", ValueNotHashed: make([]byte, 32)}
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NotEmpty(t, doc.Salts)
+	for _, salt := range doc.Salts {
+		assert.Len(t, salt.Value, 16)
+	}
+
+	// without SaltLength salts have to be 32 bytes
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: shortSalts})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample)
+	if err == nil {
+		err = doctree.Generate()
+	}
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Salt has incorrect length: 16 instead of 32")
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, SaltLength: 16})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample)
+	if err == nil {
+		err = doctree.Generate()
+	}
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Salt has incorrect length: 32 instead of at most 16")
+
+	_, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, SaltLength: -1})
+	assert.EqualError(t, err, "SaltLength can't be negative, got -1")
+}
+
+func TestTree_Redact(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleDocument{
+		ValueA:         "foo",
+		ValueB:         "bar",
+		ValueNotHashed: hashBytes(sha256Hash, []byte("baz")),
+	}))
+	assert.NoError(t, doctree.Generate())
+
+	redacted, err := doctree.Redact("valueA")
+	assert.NoError(t, err)
+	assert.Equal(t, "valueA", redacted.Property.ReadableName())
+	assert.Equal(t, testSalt, redacted.Salt)
+	_, err = doctree.Redact("valueA")
+	assert.EqualError(t, err, "valueA is already a hashed leaf")
+
+	// the redacted leaves result in the same root
+	redactedTree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, redactedTree.AddLeaves(doctree.GetLeaves()))
+	assert.NoError(t, redactedTree.Generate())
+	assert.Equal(t, doctree.RootHash(), redactedTree.RootHash())
+
+	proof, err := redactedTree.CreateProof("valueA")
+	assert.NoError(t, err)
+	assert.Empty(t, proof.Value)
+	assert.Equal(t, redacted.Hash, proof.Hash)
+	valid, err := redactedTree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	assert.EqualError(t, redactedTree.Reveal(redacted, []byte("other")), "revealed value doesn't match the redacted hash of valueA")
+	assert.NoError(t, redactedTree.Reveal(redacted, []byte("foo")))
+	assert.EqualError(t, redactedTree.Reveal(redacted, []byte("foo")), "valueA is not redacted")
+	proof, err = redactedTree.CreateProof("valueA")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("foo"), proof.Value)
+	assert.Empty(t, proof.Hash)
+	valid, err = redactedTree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = doctree.Redact("unknown")
+	assert.EqualError(t, err, "No such field: unknown in obj")
+}
+
+func TestTree_DeriveLengthSalts(t *testing.T) {
+	fieldSalts := func(compact []byte) ([]byte, error) {
+		return hashBytes(sha256Hash, compact), nil
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: fieldSalts, DeriveLengthSalts: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledRepeatedDocument))
+	assert.NoError(t, doctree.Generate())
+
+	proof, err := doctree.CreateProof("valueC.length")
+	assert.NoError(t, err)
+	expected := sha256.Sum256(append(hashBytes(sha256Hash, []byte{0, 0, 0, 3}), "length"...))
+	assert.Equal(t, expected[:], proof.Salt)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// the derived salts are reproducible from the field salts
+	reproduced, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: fieldSalts, DeriveLengthSalts: true})
+	assert.NoError(t, err)
+	assert.NoError(t, reproduced.AddLeavesFromDocument(&documentspb.ExampleFilledRepeatedDocument))
+	assert.NoError(t, reproduced.Generate())
+	assert.Equal(t, doctree.RootHash(), reproduced.RootHash())
+
+	// other leaves keep their salts
+	proof, err = doctree.CreateProof("valueC[0]")
+	assert.NoError(t, err)
+	assert.Equal(t, hashBytes(sha256Hash, []byte{0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0}), proof.Salt)
+
+	assert.Len(t, DeriveLengthSalt(testSalt[:16]), 16)
+	assert.Nil(t, DeriveLengthSalt(nil))
+}
+
+func TestTree_LeafPreimage(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	for _, leaf := range doctree.leaves {
+		value, salt, propName, err := doctree.LeafPreimage(leaf.Property.ReadableName())
+		assert.NoError(t, err)
+		assert.Equal(t, AsBytes(leaf.Property.Name(false)), propName)
+		payload, err := ConcatValues(leaf.Property.Name(false), value, salt)
+		assert.NoError(t, err)
+		assert.Equal(t, leaf.Hash, hashBytes(sha256Hash, payload))
+	}
+
+	_, _, _, err = doctree.LeafPreimage("unknown")
+	assert.EqualError(t, err, "No such field: unknown in obj")
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeaf(LeafNode{Hash: make([]byte, 32), Property: Property{Text: "Foobar1"}, Hashed: true}))
+	_, _, _, err = doctree.LeafPreimage("Foobar1")
+	assert.EqualError(t, err, "Foobar1 is a hashed leaf without preimage")
+}
+
+// panicHash fails the test if a hash is calculated
+type panicHash struct {
+	hash.Hash
+}
+
+func (panicHash) Write(p []byte) (int, error) {
+	panic("unexpected hashing")
+}
+
+func (panicHash) Sum(b []byte) []byte {
+	panic("unexpected hashing")
+}
+
+func TestFlattenStats(t *testing.T) {
+	leafCount, totalValueBytes, maxDepth, err := FlattenStats(&documentspb.LongDocumentExample, TreeOptions{Hash: panicHash{sha256.New()}})
+	assert.NoError(t, err)
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.Equal(t, len(doctree.GetLeaves()), leafCount)
+	var valueBytes, depth int
+	for _, leaf := range doctree.GetLeaves() {
+		valueBytes += len(leaf.Value)
+		if d := propertyDepth(leaf.Property); d > depth {
+			depth = d
+		}
+	}
+	assert.Equal(t, valueBytes, totalValueBytes)
+	assert.Equal(t, depth, maxDepth)
+
+	leafCount, _, maxDepth, err = FlattenStats(&documentspb.ExampleFilledNestedRepeatedDocument, TreeOptions{Hash: panicHash{sha256.New()}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, maxDepth)
+	assert.NotZero(t, leafCount)
+}
+
+func TestValidateProofHashesWithOrdering(t *testing.T) {
+	// a tree built with the children of each node in reversed order
+	reversed := func(a, b []byte, hashFunc hash.Hash) []byte {
+		return HashTwoValues(b, a, hashFunc)
+	}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, NodeHasher: reversed})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	leafHash, err := CalculateHashForProofField(&proof, sha256Hash)
+	assert.NoError(t, err)
+
+	valid, err := ValidateProofHashes(leafHash, proof.Hashes, doctree.RootHash(), sha256Hash)
+	assert.EqualError(t, err, "Hash does not match")
+	assert.False(t, valid)
+	valid, err = ValidateProofHashesWithOrdering(leafHash, proof.Hashes, doctree.RootHash(), sha256Hash, DefaultNodeOrdering)
+	assert.EqualError(t, err, "Hash does not match")
+	assert.False(t, valid)
+
+	reversedOrdering := func(node []byte, sibling *proofspb.MerkleHash) (left, right []byte) {
+		right, left = DefaultNodeOrdering(node, sibling)
+		return left, right
+	}
+	valid, err = ValidateProofHashesWithOrdering(leafHash, proof.Hashes, doctree.RootHash(), sha256Hash, reversedOrdering)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestTree_ProvablePaths(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.Empty(t, doctree.ProvablePaths())
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+	assert.NoError(t, doctree.Generate())
+
+	paths := doctree.ProvablePaths()
+	assert.Len(t, paths, len(doctree.GetLeaves()))
+	assert.Contains(t, paths, "valueC.length")
+	assert.Contains(t, paths, "valueC[0].valueA")
+	assert.Contains(t, paths, "valueC[1].valueA")
+	for _, path := range paths {
+		_, err := doctree.CreateProof(path)
+		assert.NoError(t, err)
+	}
+}
+
 func Test_SaltMessage(t *testing.T) {
 	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
 	assert.Nil(t, err)
@@ -1257,6 +3707,110 @@ func TestTree_LengthProp_Map(t *testing.T) {
 	assert.Equal(t, l.Value, el)
 }
 
+func TestTree_EnumAsString(t *testing.T) {
+	doc := &documentspb.EnumKeyDocument{Entries: []*documentspb.EnumKeyEntry{
+		{Type: documentspb.Enum_type_one, Value: "foo"},
+		{Type: documentspb.Enum_type_two, Value: "bar"},
+	}}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	_, leaf := doctree.GetLeafByProperty("entries[1]")
+	assert.NotNil(t, leaf)
+
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, EnumAsString: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("entries[type_two]")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("bar"), proof.Value)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// compact names keep the enum number
+	_, compactLeaf := doctree.GetLeafByCompactProperty([]byte{0, 0, 0, 1, 0, 0, 0, 1})
+	assert.Equal(t, "entries[type_two]", compactLeaf.Property.ReadableName())
+
+	doc.Entries[0].Type = 5
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, EnumAsString: true})
+	assert.NoError(t, err)
+	err = doctree.AddLeavesFromDocument(doc)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "enum value 5 is not defined in documents.Enum")
+}
+
+func TestTree_TypeTagValues(t *testing.T) {
+	leafHash := func(opts TreeOptions, doc proto.Message) []byte {
+		doctree, err := NewDocumentTree(opts)
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+		_, leaf := doctree.GetLeafByProperty("string_value")
+		assert.NoError(t, leaf.HashNode(sha256Hash, false))
+		return leaf.Hash
+	}
+	str := &documentspb.AllFieldTypes{StringValue: "ab"}
+	raw := &documentspb.AllFieldTypesSalts{StringValue: []byte("ab")}
+
+	opts := TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest}
+	assert.Equal(t, leafHash(opts, str), leafHash(opts, raw))
+	opts.TypeTagValues = true
+	assert.NotEqual(t, leafHash(opts, str), leafHash(opts, raw))
+
+	doctree, err := NewDocumentTree(opts)
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{TypeTagInt, 0, 0, 0, 0, 0, 0, 0, 2}, proof.Value)
+	valid, err := doctree.ValidateProof(&proof)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	doctree, err = NewDocumentTree(opts)
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(str))
+	_, leaf := doctree.GetLeafByProperty("string_value")
+	assert.Equal(t, []byte{TypeTagString, 'a', 'b'}, leaf.Value)
+}
+
+func TestTree_OmitZeroLengthLeaves(t *testing.T) {
+	doc := &documentspb.SimpleRepeatedDocument{ValueA: "foo", ValueB: "bar"}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, OmitZeroLengthLeaves: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.NoError(t, doctree.Generate())
+	_, leaf := doctree.GetLeafByProperty("valueC.length")
+	assert.Nil(t, leaf)
+
+	// the same document without the repeated field
+	flattened, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, flattened.AddLeavesFromDocument(doc))
+	var leaves []LeafNode
+	for _, leaf := range flattened.GetLeaves() {
+		if leaf.Property.ReadableName() != "valueC.length" {
+			leaves = append(leaves, leaf)
+		}
+	}
+	assert.Len(t, leaves, 2)
+	withoutField, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, withoutField.AddLeaves(leaves))
+	assert.NoError(t, withoutField.Generate())
+	assert.Equal(t, withoutField.RootHash(), doctree.RootHash())
+
+	// non empty fields keep their length leaf
+	doc.ValueC = []string{"baz"}
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, OmitZeroLengthLeaves: true})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	_, leaf = doctree.GetLeafByProperty("valueC.length")
+	assert.NotNil(t, leaf)
+}
+
 func TestTree_LengthProp_List(t *testing.T) {
 	// length is 0
 	doc := new(documentspb.RepeatedItem)
@@ -1341,6 +3895,144 @@ func Test_ReturnGeneratedSalts(t *testing.T) {
 	assert.Equal(t, hash1, hash2)
 }
 
+func Test_ReturnGeneratedSalts_KeepsProvidedSalts(t *testing.T) {
+	provided := &proofspb.Salt{Compact: []byte{0, 0, 0, 1}, Value: testSalt}
+	doc := &documentspb.ContainSalts{ValueA: "TestA", ValueB: 5, Salts: []*proofspb.Salt{provided}}
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.Len(t, doc.Salts, 2)
+	assert.Equal(t, provided, doc.Salts[0])
+	assert.Equal(t, []byte{0, 0, 0, 2}, doc.Salts[1].Compact)
+	assert.Len(t, doc.Salts[1].Value, 32)
+	assert.Equal(t, testSalt, doctree.GetLeaves()[0].Salt)
+	assert.Equal(t, doc.Salts[1].Value, doctree.GetLeaves()[1].Salt)
+
+	// nothing is written back if all salts are provided
+	salts := doc.Salts
+	doctree, err = NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	assert.Equal(t, salts, doc.Salts)
+}
+
+func TestValidateSaltsComplete(t *testing.T) {
+	doc := &documentspb.ContainSalts{ValueA: "TestA", ValueB: 5, Salts: []*proofspb.Salt{{Compact: []byte{0, 0, 0, 1}, Value: testSalt}}}
+	missing, err := ValidateSaltsComplete(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"00000002"}, missing)
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	missing, err = ValidateSaltsComplete(doc)
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+
+	_, err = ValidateSaltsComplete(&documentspb.ExampleWithoutSalts{ValueA: "TestA"})
+	assert.EqualError(t, err, "Cannot find salts field in message")
+}
+
+func TestValidateSaltUniqueness(t *testing.T) {
+	reused := make([]byte, 32)
+	reused[0] = 1
+	doc := &documentspb.SimpleRepeatedDocument{ValueA: "foo", ValueB: "bar", Salts: []*proofspb.Salt{
+		{Compact: []byte{0, 0, 0, 1}, Value: reused},
+		{Compact: []byte{0, 0, 0, 2}, Value: make([]byte, 32)},
+		{Compact: []byte{0, 0, 0, 3}, Value: reused},
+	}}
+	names, err := ValidateSaltUniqueness(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"00000001", "00000003"}, names)
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash})
+	assert.NoError(t, err)
+	doc = &documentspb.SimpleRepeatedDocument{ValueA: "foo", ValueB: "bar"}
+	assert.NoError(t, doctree.AddLeavesFromDocument(doc))
+	names, err = ValidateSaltUniqueness(doc)
+	assert.NoError(t, err)
+	assert.Empty(t, names)
+
+	_, err = ValidateSaltUniqueness(&documentspb.ExampleWithoutSalts{ValueA: "TestA"})
+	assert.EqualError(t, err, "Cannot find salts field in message")
+}
+
+func BenchmarkAddLeavesFromDocument_GeneratedSalts(b *testing.B) {
+	values := make([]string, 5000)
+	for i := range values {
+		values[i] = strconv.Itoa(i)
+	}
+	for i := 0; i < b.N; i++ {
+		doc := &documentspb.SimpleRepeatedDocument{ValueA: "foo", ValueC: values}
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256.New()})
+		if err != nil {
+			b.Fatal(err)
+		}
+		err = doctree.AddLeavesFromDocument(doc)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCreateProofsParallel(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	var props []string
+	for _, prop := range doctree.PropertyOrder() {
+		props = append(props, prop.ReadableName())
+	}
+	proofs, err := CreateProofsParallel(&doctree, props, 4)
+	assert.NoError(t, err)
+	assert.Len(t, proofs, len(props))
+	for i, prop := range props {
+		assert.Equal(t, prop, proofs[i].GetReadableName())
+		valid, err := doctree.ValidateProof(&proofs[i])
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+
+	_, err = CreateProofsParallel(&doctree, []string{"value1", "unknown"}, 2)
+	assert.EqualError(t, err, "No such field: unknown in obj")
+
+	_, err = CreateProofsParallel(&doctree, props, 0)
+	assert.EqualError(t, err, "workers has to be at least 1, got 0")
+}
+
+func BenchmarkCreateProofsParallel(b *testing.B) {
+	values := make([]string, 1000)
+	for i := range values {
+		values[i] = strconv.Itoa(i)
+	}
+	doctree, _ := NewDocumentTree(TreeOptions{Hash: sha256.New(), Salts: NewSaltForTest})
+	_ = doctree.AddLeavesFromDocument(&documentspb.SimpleRepeatedDocument{ValueA: "foo", ValueC: values})
+	_ = doctree.Generate()
+	var props []string
+	for _, prop := range doctree.PropertyOrder() {
+		props = append(props, prop.ReadableName())
+	}
+
+	b.Run("Sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, prop := range props {
+				if _, err := doctree.CreateProof(prop); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := CreateProofsParallel(&doctree, props, runtime.GOMAXPROCS(0)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func Test_MessageWithoutSaltsField(t *testing.T) {
 	doc := new(documentspb.ExampleWithoutSalts)
 	doc.ValueA = "TestA"
@@ -1358,14 +4050,38 @@ func TestTree_AddTwoLeavesWithSameReadableName(t *testing.T) {
 	err = tree.AddLeaf(LeafNode{Hash: hashLeafA[:], Property: NewProperty("LeafA", 1), Hashed: true})
 	assert.Nil(t, err)
 	err = tree.AddLeaf(LeafNode{Hash: hashLeafA[:], Property: NewProperty("LeafA", 2), Hashed: true})
-	assert.EqualError(t, err, "duplicated leaf")
+	assert.EqualError(t, err, "duplicated leaf: readable name LeafA is already used")
+	// the rejected leaf doesn't reserve its compact name
+	err = tree.AddLeaf(LeafNode{Hash: hashLeafA[:], Property: NewProperty("LeafC", 2), Hashed: true})
+	assert.Nil(t, err)
 
 	tree2, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256.New(), Salts: NewSaltForTest})
 	assert.Nil(t, err)
 	err = tree2.AddLeaves([]LeafNode{LeafNode{Hash: hashLeafA[:], Property: NewProperty("LeafA", 1), Hashed: true},
 		LeafNode{Hash: hashLeafA[:], Property: NewProperty("LeafA", 2), Hashed: true}})
 
-	assert.EqualError(t, err, "duplicated leaf")
+	assert.EqualError(t, err, "duplicated leaf: readable name LeafA is already used")
+}
+
+func TestTree_LengthSuffixCollision(t *testing.T) {
+	doc := &documentspb.LengthFieldDocument{Values: []string{"foo"}, Length: "bar"}
+	tree, err := NewDocumentTree(TreeOptions{Hash: sha256.New(), Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, tree.AddLeavesFromDocument(doc))
+	_, leaf := tree.GetLeafByProperty("length")
+	assert.NotNil(t, leaf)
+	_, leaf = tree.GetLeafByProperty("values.length")
+	assert.NotNil(t, leaf)
+
+	// a field of a message named like the repeated field collides with its length leaf
+	collidingProp := Empty.FieldProp("values", 4).FieldProp("length", 1)
+	err = tree.AddLeaf(LeafNode{Property: collidingProp, Value: []byte("baz"), Salt: testSalt})
+	assert.EqualError(t, err, "duplicated leaf: readable name values.length of a field collides with the length leaf of a repeated or map field, set ReadablePropertyLengthSuffix to a name that no field uses")
+
+	tree, err = NewDocumentTree(TreeOptions{Hash: sha256.New(), Salts: NewSaltForTest, ReadablePropertyLengthSuffix: "count"})
+	assert.NoError(t, err)
+	assert.NoError(t, tree.AddLeavesFromDocument(doc))
+	assert.NoError(t, tree.AddLeaf(LeafNode{Property: collidingProp, Value: []byte("baz"), Salt: testSalt}))
 }
 
 func TestTree_AddTwoLeavesWithSameCompactName(t *testing.T) {
@@ -1376,14 +4092,14 @@ func TestTree_AddTwoLeavesWithSameCompactName(t *testing.T) {
 	err = tree.AddLeaf(LeafNode{Hash: hashLeafA[:], Property: NewProperty("LeafA", 1), Hashed: true})
 	assert.Nil(t, err)
 	err = tree.AddLeaf(LeafNode{Hash: hashLeafA[:], Property: NewProperty("LeafB", 1), Hashed: true})
-	assert.EqualError(t, err, "duplicated leaf")
+	assert.EqualError(t, err, "duplicated leaf: compact name 01 of LeafB is already used by LeafA")
 
 	tree2, err := NewDocumentTree(TreeOptions{EnableHashSorting: true, Hash: sha256.New(), Salts: NewSaltForTest})
 	assert.Nil(t, err)
 	err = tree2.AddLeaves([]LeafNode{LeafNode{Hash: hashLeafA[:], Property: NewProperty("LeafA", 1), Hashed: true},
 		LeafNode{Hash: hashLeafA[:], Property: NewProperty("LeafB", 1), Hashed: true}})
 
-	assert.EqualError(t, err, "duplicated leaf")
+	assert.EqualError(t, err, "duplicated leaf: compact name 01 of LeafB is already used by LeafA")
 }
 
 func TestTree_TooLongStringAndBytes(t *testing.T) {
@@ -1476,6 +4192,42 @@ func TestTree_TreeDepthArg(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestTree_CreateUniformProof(t *testing.T) {
+	docs := []proto.Message{
+		&documentspb.LongDocumentExample,
+		&documentspb.ExampleFilledRepeatedDocument,
+		&documentspb.ContainSalts{ValueA: "a"},
+	}
+	for _, doc := range docs {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: 5})
+		assert.Nil(t, err)
+		err = doctree.AddLeavesFromDocument(doc)
+		assert.Nil(t, err)
+		err = doctree.Generate()
+		assert.Nil(t, err)
+
+		for _, prop := range doctree.PropertyOrder() {
+			proof, err := doctree.CreateUniformProof(prop.ReadableName())
+			assert.Nil(t, err)
+			assert.Len(t, proof.Hashes, 5)
+			valid, err := doctree.ValidateProof(&proof)
+			assert.Nil(t, err)
+			assert.True(t, valid)
+		}
+		_, err = doctree.CreateUniformProof("inexistent")
+		assert.EqualError(t, err, "No such field: inexistent in obj")
+	}
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.Nil(t, err)
+	err = doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample)
+	assert.Nil(t, err)
+	err = doctree.Generate()
+	assert.Nil(t, err)
+	_, err = doctree.CreateUniformProof("value1")
+	assert.EqualError(t, err, "uniform proofs require a fixed depth tree")
+}
+
 func TestTree_Blake2b512LeafSha256InternalHashFunction(t *testing.T) {
 	doctree, err := NewDocumentTree(TreeOptions{
 		Hash:     sha256Hash,
@@ -1583,6 +4335,251 @@ func TestTree_FixedSizeTreeDoNotSupportSortingByHash(t *testing.T) {
 	assert.Equal(t, "Fixed size tree does not support sorting by hash", err.Error())
 }
 
+func TestLeafIndexFromHashes(t *testing.T) {
+	for leafCount := 1; leafCount <= 40; leafCount++ {
+		leaves := make([][]byte, leafCount)
+		for i := range leaves {
+			leaves[i] = []byte{byte(i)}
+		}
+		tree := newUnbalancedTree(sha256.New(), HashTwoValues, false)
+		assert.NoError(t, tree.Generate(leaves, 0))
+		for i := 0; i < leafCount; i++ {
+			nodes, err := tree.GetMerkleProof(uint(i))
+			assert.NoError(t, err)
+			var hashes []*proofspb.MerkleHash
+			for _, n := range nodes {
+				if n.Left {
+					hashes = append(hashes, &proofspb.MerkleHash{Left: n.Hash})
+				} else {
+					hashes = append(hashes, &proofspb.MerkleHash{Right: n.Hash})
+				}
+			}
+			index, err := leafIndexFromHashes(hashes, uint64(leafCount))
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(i), index)
+		}
+	}
+
+	_, err := leafIndexFromHashes(nil, 15)
+	assert.EqualError(t, err, "proof has too few hashes for the tree size")
+	_, err = leafIndexFromHashes([]*proofspb.MerkleHash{{Right: []byte{1}}}, 1)
+	assert.EqualError(t, err, "proof has too many hashes for the tree size")
+}
+
+func TestProofsCoverAllLeaves(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	var proofs []*proofspb.Proof
+	for _, leaf := range doctree.GetLeaves() {
+		proof, err := doctree.CreateProof(leaf.Property.ReadableName())
+		assert.NoError(t, err)
+		proofs = append(proofs, &proof)
+	}
+	covered, err := ProofsCoverAllLeaves(proofs, len(doctree.GetLeaves()))
+	assert.NoError(t, err)
+	assert.True(t, covered)
+
+	// a missing field leaves a gap, a duplicated proof doesn't close it
+	incomplete := append(append([]*proofspb.Proof{}, proofs[:3]...), proofs[4:]...)
+	covered, err = ProofsCoverAllLeaves(incomplete, len(doctree.GetLeaves()))
+	assert.NoError(t, err)
+	assert.False(t, covered)
+	covered, err = ProofsCoverAllLeaves(append(incomplete, proofs[0]), len(doctree.GetLeaves()))
+	assert.NoError(t, err)
+	assert.False(t, covered)
+
+	_, err = ProofsCoverAllLeaves(proofs, 100)
+	assert.EqualError(t, err, "proof 0: proof has too few hashes for the tree size")
+	_, err = ProofsCoverAllLeaves([]*proofspb.Proof{{SortedHashes: [][]byte{{1}}}}, 2)
+	assert.EqualError(t, err, "proof 0 uses sorted hashes which don't reveal the leaf position")
+}
+
+func TestCalculateHeightAndNodeCount(t *testing.T) {
+	for leafCount := uint64(1); leafCount < 300; leafCount++ {
+		height, nodeCount := calculateHeightAndNodeCount(leafCount)
+		levels := uint64(1)
+		count := leafCount
+		total := leafCount
+		for count > 1 {
+			count = (count + 1) / 2
+			total += count
+			levels++
+		}
+		assert.Equal(t, levels, height)
+		assert.Equal(t, total, nodeCount)
+	}
+	height, nodeCount := calculateHeightAndNodeCount(0)
+	assert.Equal(t, uint64(0), height)
+	assert.Equal(t, uint64(0), nodeCount)
+}
+
+func TestCalculateProofNodeList(t *testing.T) {
+	tests := []struct {
+		node, leafCount uint64
+		result          []*HashNode
+	}{
+		{0, 15, []*HashNode{{false, 1}, {false, 16}, {false, 24}, {false, 28}}},
+		{1, 15, []*HashNode{{true, 0}, {false, 16}, {false, 24}, {false, 28}}},
+		{2, 15, []*HashNode{{false, 3}, {true, 15}, {false, 24}, {false, 28}}},
+		{5, 15, []*HashNode{{true, 4}, {false, 18}, {true, 23}, {false, 28}}},
+		{13, 15, []*HashNode{{true, 12}, {false, 22}, {true, 25}, {true, 27}}},
+		{14, 15, []*HashNode{{true, 21}, {true, 25}, {true, 27}}},
+		{2, 3, []*HashNode{{true, 3}}},
+		{0, 1, nil},
+	}
+	for _, test := range tests {
+		nodes, err := CalculateProofNodeList(test.node, test.leafCount)
+		assert.NoError(t, err)
+		assert.Equal(t, test.result, nodes, "node %d of %d", test.node, test.leafCount)
+	}
+
+	_, err := CalculateProofNodeList(15, 15)
+	assert.EqualError(t, err, "node index is too big for node count")
+
+	// left/right designation matches the proofs of a generated tree
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	assert.Len(t, doctree.leaves, 15)
+	for i := range doctree.leaves {
+		hashes, err := doctree.pickHashesFromMerkleTree(uint64(i))
+		assert.NoError(t, err)
+		nodes, err := CalculateProofNodeList(uint64(i), 15)
+		assert.NoError(t, err)
+		assert.Len(t, nodes, len(hashes))
+		for j := range nodes {
+			assert.Equal(t, nodes[j].Left, len(hashes[j].Left) > 0)
+		}
+	}
+}
+
+func TestProofCoverage(t *testing.T) {
+	coverage, err := ProofCoverage(0, 15)
+	assert.NoError(t, err)
+	// siblings 1, 16, 24, 28 and path 0, 15, 23, 27, 29 of TestCalculateProofNodeList
+	assert.Equal(t, []uint64{0, 1, 15, 16, 23, 24, 27, 28, 29}, coverage)
+
+	coverage, err = ProofCoverage(14, 15)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{14, 21, 22, 25, 26, 27, 28, 29}, coverage)
+
+	coverage, err = ProofCoverage(0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0}, coverage)
+
+	_, err = ProofCoverage(15, 15)
+	assert.EqualError(t, err, "node index is too big for node count")
+	_, err = ProofCoverage(-1, 15)
+	assert.EqualError(t, err, "node index can't be negative")
+}
+
+func TestProofsEqual(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, EnableHashSorting: true, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	first, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	second, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+	other, err := doctree.CreateProof("value2")
+	assert.NoError(t, err)
+	assert.True(t, ProofsEqual(&first, &second))
+	assert.False(t, ProofsEqual(&first, &other))
+
+	// sorted hashes are compared as a set
+	second.SortedHashes[0], second.SortedHashes[1] = second.SortedHashes[1], second.SortedHashes[0]
+	assert.True(t, ProofsEqual(&first, &second))
+	second.SortedHashes[0] = second.SortedHashes[1]
+	assert.False(t, ProofsEqual(&first, &second))
+
+	// standard hashes are compared in order
+	standard := StandardToSorted(&first)
+	assert.False(t, ProofsEqual(&first, standard))
+	first.Hashes = []*proofspb.MerkleHash{{Left: []byte{1}}, {Right: []byte{2}}}
+	reordered := proto.Clone(&first).(*proofspb.Proof)
+	assert.True(t, ProofsEqual(&first, reordered))
+	reordered.Hashes[0], reordered.Hashes[1] = reordered.Hashes[1], reordered.Hashes[0]
+	assert.False(t, ProofsEqual(&first, reordered))
+
+	// the same bytes as a readable and compact name differ
+	readable := &proofspb.Proof{Property: ReadableName("a")}
+	compact := &proofspb.Proof{Property: CompactName('a')}
+	assert.False(t, ProofsEqual(readable, compact))
+}
+
+func TestCanonicalizeProof(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+	proof, err := doctree.CreateProof("value1")
+	assert.NoError(t, err)
+
+	other := proto.Clone(&proof).(*proofspb.Proof)
+	other.Hash = []byte{}
+	other.SortedHashes = [][]byte{}
+	other.ProtoReflect().SetUnknown([]byte{0x50, 0x01})
+	marshal := func(p *proofspb.Proof) ([]byte, error) {
+		buf := proto.NewBuffer(nil)
+		buf.SetDeterministic(true)
+		err := buf.Marshal(p)
+		return buf.Bytes(), err
+	}
+	first, err := marshal(&proof)
+	assert.NoError(t, err)
+	second, err := marshal(other)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	first, err = marshal(CanonicalizeProof(&proof))
+	assert.NoError(t, err)
+	second, err = marshal(CanonicalizeProof(other))
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	canonical := CanonicalizeProof(&proofspb.Proof{Property: &proofspb.Proof_CompactName{CompactName: []byte{}}, Salt: []byte{}})
+	assert.Nil(t, canonical.Property)
+	assert.Nil(t, canonical.Salt)
+	valid, err := doctree.ValidateProof(CanonicalizeProof(other))
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestStandardToSorted(t *testing.T) {
+	doc := &documentspb.ExampleWithoutSalts{ValueA: "foo", ValueB: 42}
+	standard, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, standard.AddLeavesFromDocument(doc))
+	assert.NoError(t, standard.Generate())
+
+	sorted, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, EnableHashSorting: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sorted.AddLeavesFromDocument(doc))
+	assert.NoError(t, sorted.Generate())
+
+	for _, prop := range []string{"valueA", "valueB"} {
+		proof, err := standard.CreateProof(prop)
+		assert.NoError(t, err)
+		converted := StandardToSorted(&proof)
+		assert.Nil(t, converted.Hashes)
+		assert.Len(t, converted.SortedHashes, 1)
+		assert.Equal(t, proof.Value, converted.Value)
+		assert.Len(t, proof.Hashes, 1, "the original proof is left untouched")
+
+		fieldHash, err := CalculateHashForProofField(converted, sha256Hash)
+		assert.NoError(t, err)
+		valid, err := ValidateProofSortedHashes(fieldHash, converted.SortedHashes, sorted.RootHash(), sha256Hash)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+}
+
 func TestOptimizeProofs(t *testing.T) {
 	// nil input
 	opt, err := OptimizeProofs(nil, nil, sha256.New())
@@ -1763,6 +4760,190 @@ func TestOptimizeProofs(t *testing.T) {
 		origHashesCount += len(original[i].SortedHashes)
 	}
 	fmt.Printf("Original[%d] -> Optimized[%d] with factor[%f]\n", origHashesCount, optHashesCount, float64(optHashesCount)/float64(origHashesCount))
+
+	valid, err := ValidateOptimizedProofs(opt, docRoot, sha256.New())
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestTree_SchemeVersion(t *testing.T) {
+	newTree := func(version byte, sorted bool) DocumentTree {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, SchemeVersion: version, EnableHashSorting: sorted})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+		assert.NoError(t, doctree.Generate())
+		return doctree
+	}
+
+	for _, sorted := range []bool{false, true} {
+		unversioned := newTree(0, sorted)
+		v1 := newTree(1, sorted)
+		v2 := newTree(2, sorted)
+		assert.NotEqual(t, unversioned.RootHash(), v1.RootHash())
+		assert.NotEqual(t, v1.RootHash(), v2.RootHash())
+
+		// leaf hashes are versioned as well
+		_, leaf1 := v1.GetLeafByProperty("valueA")
+		_, leaf2 := v2.GetLeafByProperty("valueA")
+		assert.NotEqual(t, leaf1.Hash, leaf2.Hash)
+		value, salt, propName, err := v2.LeafPreimage("valueA")
+		assert.NoError(t, err)
+		assert.Equal(t, hashBytes(sha256Hash, append([]byte{2}, append(append(propName, value...), salt...)...)), leaf2.Hash)
+
+		proof1, err := v1.CreateProof("valueD.valueA.valueA")
+		assert.NoError(t, err)
+		proof2, err := v2.CreateProof("valueD.valueA.valueA")
+		assert.NoError(t, err)
+
+		valid, err := v1.ValidateProof(&proof1)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+		valid, err = v2.ValidateProof(&proof2)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		// proofs don't validate against the root of another version, even if the verifier knows that root
+		v2Verifier, err := NewDocumentTreeWithRootHash(TreeOptions{Hash: sha256Hash, SchemeVersion: 2, EnableHashSorting: sorted}, v1.RootHash())
+		assert.NoError(t, err)
+		valid, _ = v2Verifier.ValidateProof(&proof1)
+		assert.False(t, valid)
+		v1Verifier, err := NewDocumentTreeWithRootHash(TreeOptions{Hash: sha256Hash, SchemeVersion: 1, EnableHashSorting: sorted}, v2.RootHash())
+		assert.NoError(t, err)
+		valid, _ = v1Verifier.ValidateProof(&proof2)
+		assert.False(t, valid)
+		valid, _ = v1.ValidateProof(&proof2)
+		assert.False(t, valid)
+		valid, _ = v2.ValidateProof(&proof1)
+		assert.False(t, valid)
+	}
+
+	_, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, SchemeVersion: 1, Arity: 4})
+	assert.EqualError(t, err, "Arity above 2 can't be combined with TreeDepth, PadToPowerOfTwo, EnableHashSorting, NodeHasher or SchemeVersion")
+}
+
+func TestProofSizeComparison(t *testing.T) {
+	for _, sorted := range []bool{false, true} {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, EnableHashSorting: sorted})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+		assert.NoError(t, doctree.Generate())
+
+		proof, err := doctree.CreateProof("valueD.valueA.valueA")
+		assert.NoError(t, err)
+		standardBytes, sortedBytes, err := ProofSizeComparison(&doctree, "valueD.valueA.valueA")
+		assert.NoError(t, err)
+		if sorted {
+			assert.Equal(t, proto.Size(&proof), sortedBytes)
+		} else {
+			assert.Equal(t, proto.Size(&proof), standardBytes)
+		}
+		// sorted proofs don't wrap every hash into a left/right message
+		assert.True(t, sortedBytes < standardBytes, "%d sorted bytes, %d standard bytes", sortedBytes, standardBytes)
+	}
+
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, Salts: NewSaltForTest, TreeDepth: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.ExampleFilledNestedRepeatedDocument))
+	assert.NoError(t, doctree.Generate())
+	_, _, err = ProofSizeComparison(&doctree, "valueA")
+	assert.EqualError(t, err, "trees with a fixed size or an Arity above 2 only support one proof mode")
+}
+
+func TestValidateOptimizedProofs(t *testing.T) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, EnableHashSorting: true, Salts: NewSaltForTest})
+	assert.NoError(t, err)
+	assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(t, doctree.Generate())
+
+	var original []*proofspb.Proof
+	for _, prop := range doctree.PropertyOrder() {
+		proof, err := doctree.CreateProof(prop.ReadableName())
+		assert.NoError(t, err)
+		original = append(original, &proof)
+	}
+	opt, err := OptimizeProofs(original, doctree.RootHash(), sha256.New())
+	assert.NoError(t, err)
+
+	valid, err := ValidateOptimizedProofs(opt, doctree.RootHash(), sha256.New())
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// the shortened proofs rely on the nodes established by the proofs before them
+	valid, err = ValidateOptimizedProofs(opt[1:], doctree.RootHash(), sha256.New())
+	assert.EqualError(t, err, "proof 0: Hash does not match")
+	assert.False(t, valid)
+
+	_, err = ValidateOptimizedProofs(opt, make([]byte, 32), sha256.New())
+	assert.EqualError(t, err, "proof 0: Hash does not match")
+
+	_, err = ValidateOptimizedProofs(nil, doctree.RootHash(), sha256.New())
+	assert.EqualError(t, err, "no proofs to validate")
+}
+
+func TestProofVerifierCache(t *testing.T) {
+	for _, sorted := range []bool{false, true} {
+		doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, EnableHashSorting: sorted, Salts: NewSaltForTest})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+		assert.NoError(t, doctree.Generate())
+
+		cache := NewProofVerifierCache(sha256.New())
+		var steps int
+		for _, prop := range doctree.PropertyOrder() {
+			proof, err := doctree.CreateProof(prop.ReadableName())
+			assert.NoError(t, err)
+			valid, err := cache.ValidateProof(&proof, doctree.RootHash())
+			assert.NoError(t, err)
+			assert.True(t, valid)
+			steps += len(proof.Hashes) + len(proof.SortedHashes)
+		}
+		// shared pairs are only hashed once
+		assert.True(t, len(cache.parents) < steps/2)
+
+		// memoized parents don't make tampered proofs valid
+		proof, err := doctree.CreateProof("value1")
+		assert.NoError(t, err)
+		proof.Value = []byte("tampered")
+		valid, err := cache.ValidateProof(&proof, doctree.RootHash())
+		assert.EqualError(t, err, "Hash does not match")
+		assert.False(t, valid)
+	}
+}
+
+func BenchmarkProofVerifierCache(b *testing.B) {
+	doctree, err := NewDocumentTree(TreeOptions{Hash: sha256Hash, EnableHashSorting: true, Salts: NewSaltForTest})
+	assert.NoError(b, err)
+	assert.NoError(b, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+	assert.NoError(b, doctree.Generate())
+	var proofs []*proofspb.Proof
+	for _, prop := range doctree.PropertyOrder() {
+		proof, err := doctree.CreateProof(prop.ReadableName())
+		assert.NoError(b, err)
+		proofs = append(proofs, &proof)
+	}
+
+	b.Run("Naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, proof := range proofs {
+				leaf, _ := CalculateHashForProofField(proof, sha256Hash)
+				valid, err := ValidateProofSortedHashes(leaf, proof.SortedHashes, doctree.RootHash(), sha256Hash)
+				if !valid {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("Cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cache := NewProofVerifierCache(sha256Hash)
+			for _, proof := range proofs {
+				valid, err := cache.ValidateProof(proof, doctree.RootHash())
+				if !valid {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
 }
 
 func convertProof(t *testing.T, property, value, salt, hash string, hashes []string) *proofspb.Proof {
@@ -0,0 +1,102 @@
+/*
+Package verify validates proofs created by the proofs package without depending on a merkle tree implementation.
+
+It only imports the generated protobuf types of the proofs, so verifiers, e.g. WebAssembly or embedded clients, don't
+pull in the code to build trees. The results are identical to the validation functions of the proofs package with
+the default leaf and node hashing.
+*/
+package verify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+
+	proofspb "github.com/centrifuge/precise-proofs/proofs/proto"
+)
+
+// VerifyStandalone validates the proof against the root. The hash of the leaf is calculated from the property, value
+// and salt of the proof unless the proof contains the hash of a hashed field. If sorted is set, the sorted hashes of
+// the proof are used.
+func VerifyStandalone(proof *proofspb.Proof, rootHash []byte, hashFunc hash.Hash, sorted bool) (valid bool, err error) {
+	leafHash := proof.Hash
+	if len(leafHash) == 0 {
+		leafHash, err = LeafHash(proof, hashFunc)
+		if err != nil {
+			return false, err
+		}
+	}
+	if sorted {
+		return SortedHashes(leafHash, proof.SortedHashes, rootHash, hashFunc)
+	}
+	return ProofHashes(leafHash, proof.Hashes, rootHash, hashFunc)
+}
+
+// LeafHash calculates the hash of the leaf of the proof from the concatenation of its property name, value and salt
+func LeafHash(proof *proofspb.Proof, hashFunc hash.Hash) ([]byte, error) {
+	var name []byte
+	switch pn := proof.Property.(type) {
+	case *proofspb.Proof_ReadableName:
+		name = []byte(pn.ReadableName)
+	case *proofspb.Proof_CompactName:
+		name = pn.CompactName
+	}
+	if len(proof.Salt) > 0 && len(proof.Salt) != 32 {
+		return nil, fmt.Errorf("%s: Salt has incorrect length: %d instead of 32", proof.Property, len(proof.Salt))
+	}
+
+	payload := make([]byte, 0, len(name)+len(proof.Value)+len(proof.Salt))
+	payload = append(payload, name...)
+	payload = append(payload, proof.Value...)
+	payload = append(payload, proof.Salt...)
+	return sum(hashFunc, payload), nil
+}
+
+// ProofHashes calculates the merkle root based on a list of left/right hashes and compares it to rootHash
+func ProofHashes(hash []byte, hashes []*proofspb.MerkleHash, rootHash []byte, hashFunc hash.Hash) (valid bool, err error) {
+	for i := 0; i < len(hashes); i++ {
+		if len(hashes[i].Left) == 0 {
+			hash = hashTwoValues(hash, hashes[i].Right, hashFunc)
+		} else {
+			hash = hashTwoValues(hashes[i].Left, hash, hashFunc)
+		}
+	}
+	if !bytes.Equal(hash, rootHash) {
+		return false, errors.New("Hash does not match")
+	}
+
+	return true, nil
+}
+
+// SortedHashes calculates the merkle root based on a list of sorted hashes and compares it to rootHash
+func SortedHashes(hash []byte, hashes [][]byte, rootHash []byte, hashFunc hash.Hash) (valid bool, err error) {
+	for i := 0; i < len(hashes); i++ {
+		if bytes.Compare(hash, hashes[i]) > 0 {
+			hash = hashTwoValues(hashes[i], hash, hashFunc)
+		} else {
+			hash = hashTwoValues(hash, hashes[i], hashFunc)
+		}
+	}
+	if !bytes.Equal(hash, rootHash) {
+		return false, errors.New("Hash does not match")
+	}
+
+	return true, nil
+}
+
+func hashTwoValues(a []byte, b []byte, hashFunc hash.Hash) []byte {
+	data := make([]byte, len(a)+len(b))
+	copy(data[:len(a)], a)
+	copy(data[len(a):], b)
+	return sum(hashFunc, data)
+}
+
+func sum(hashFunc hash.Hash, input []byte) []byte {
+	defer hashFunc.Reset()
+	_, err := hashFunc.Write(input)
+	if err != nil {
+		return []byte{}
+	}
+	return hashFunc.Sum(nil)
+}
@@ -0,0 +1,60 @@
+package verify_test
+
+import (
+	"crypto/sha256"
+	"go/build"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/precise-proofs/examples/documents"
+	"github.com/centrifuge/precise-proofs/proofs"
+	"github.com/centrifuge/precise-proofs/proofs/verify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyStandalone(t *testing.T) {
+	for _, sorted := range []bool{false, true} {
+		doctree, err := proofs.NewDocumentTree(proofs.TreeOptions{Hash: sha256.New(), EnableHashSorting: sorted, Salts: func(compact []byte) ([]byte, error) {
+			return make([]byte, 32), nil
+		}})
+		assert.NoError(t, err)
+		assert.NoError(t, doctree.AddLeavesFromDocument(&documentspb.LongDocumentExample))
+		assert.NoError(t, doctree.Generate())
+
+		proof, err := doctree.CreateProof("value1")
+		assert.NoError(t, err)
+		valid, err := verify.VerifyStandalone(&proof, doctree.RootHash(), sha256.New(), sorted)
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		proof.Value = []byte{1}
+		valid, err = verify.VerifyStandalone(&proof, doctree.RootHash(), sha256.New(), sorted)
+		assert.EqualError(t, err, "Hash does not match")
+		assert.False(t, valid)
+	}
+}
+
+func TestVerifyStandalone_Imports(t *testing.T) {
+	seen := make(map[string]bool)
+	var walk func(path string)
+	walk = func(path string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		pkg, err := build.Import(path, ".", 0)
+		if !assert.NoError(t, err) || pkg.Goroot {
+			return
+		}
+		for _, imp := range pkg.Imports {
+			walk(imp)
+		}
+	}
+	walk("github.com/centrifuge/precise-proofs/proofs/verify")
+
+	assert.True(t, seen["github.com/centrifuge/precise-proofs/proofs/proto"])
+	for path := range seen {
+		assert.False(t, strings.Contains(path, "go-merkle"), path)
+		assert.NotEqual(t, "github.com/centrifuge/precise-proofs/proofs", path)
+	}
+}